@@ -12,6 +12,9 @@ var (
 	// FS is the embedded file system for all assets.
 	//go:embed *.png *.json
 	FS embed.FS
+	// SoundsFS holds the wav sound effects and music loops.
+	//go:embed sounds/*.wav
+	SoundsFS embed.FS
 
 	//go:embed m5x7.ttf
 	m5x7File []byte
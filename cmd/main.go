@@ -1,8 +1,8 @@
 package main
 
 import (
+	"flag"
 	"game/game"
-	"game/vars"
 	"log"
 	"runtime"
 
@@ -113,17 +113,41 @@ import (
 */
 
 func main() {
-	ebiten.SetWindowSize(vars.ScreenWidth*vars.Scale, vars.ScreenHeight*vars.Scale)
+	record := flag.String("record", "", "write the input of this session to a file")
+	replay := flag.String("replay", "", "play back the input recorded in a file")
+	slot := flag.Int("slot", 0, "save slot to play, skipping the slot select menu")
+	flag.Parse()
+
+	stopRecording := func() error { return nil }
+	if *replay != "" {
+		var err error
+		if *slot, err = game.Replay(*replay); err != nil {
+			log.Fatal(err)
+		}
+	} else if *record != "" {
+		var err error
+		if stopRecording, err = game.Record(*record, *slot); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := game.LoadConfig(); err != nil {
+		log.Println("error loading config:", err)
+	}
+	game.ApplyConfig()
 	ebiten.SetWindowTitle("Castle")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
-	ebiten.SetVsyncEnabled(!vars.Debug)
 
 	// TODO: Prevent macOS from using Metal API and panic.
 	op := &ebiten.RunGameOptions{}
 	if runtime.GOOS == "darwin" {
 		op.GraphicsLibrary = ebiten.GraphicsLibraryOpenGL
 	}
-	if err := ebiten.RunGameWithOptions(&game.Game{}, op); err != nil {
+	err := ebiten.RunGameWithOptions(&game.Game{Slot: *slot}, op)
+	if err := stopRecording(); err != nil {
+		log.Println("error saving input recording:", err)
+	}
+	if err != nil {
 		log.Fatal(err)
 	}
 }
@@ -1,36 +1,91 @@
 package main_test
 
 import (
-	"errors"
+	"bytes"
+	"game/comps/stats"
+	"game/core"
 	"game/game"
-	"log"
-	"os"
+	"game/utils"
+	"game/vars"
 	"testing"
-
-	"github.com/hajimehoshi/ebiten/v2"
 )
 
-type testGame struct {
-	game   *game.Game
-	frames int
-}
+const frames = 3000
 
-func (g *testGame) Update() error {
-	if g.frames--; g.frames <= 0 {
-		return ebiten.Termination
+func TestRun(t *testing.T) {
+	h := game.NewHeadless(1, false, nil)
+	h.Input.Hold(60, 300, utils.KeyRight)
+	h.Input.Press(120, utils.KeyJump)
+	h.Input.Press(200, utils.KeyAction)
+	h.At(frames-1, func(world *core.World) {
+		if len(world.GetAll()) == 0 {
+			t.Error("world has no entities")
+		}
+		if core.Get[*stats.Comp](vars.Player) == nil {
+			t.Error("player has no stats")
+		}
+		found := false
+		for entity, s := range core.Each[*stats.Comp](world) {
+			if s == nil {
+				t.Errorf("%T has a nil stats", entity)
+			}
+			found = found || entity == vars.Player
+		}
+		if !found {
+			t.Error("player not found among the entities with stats")
+		}
+	})
+	if err := h.Run(frames); err != nil {
+		t.Fatal(err)
 	}
+}
 
-	return g.game.Update()
+func TestRunDeterministic(t *testing.T) {
+	var positions [2][2]float64
+	for i := range positions {
+		h := game.NewHeadless(1, false, nil)
+		h.Input.Hold(30, 120, utils.KeyLeft)
+		h.Input.Press(90, utils.KeyJump)
+		if err := h.Run(frames / 5); err != nil {
+			t.Fatal(err)
+		}
+		positions[i][0], positions[i][1] = vars.Player.Position()
+	}
+	if positions[0] != positions[1] {
+		t.Errorf("runs with the same seed and input diverged: %v != %v", positions[0], positions[1])
+	}
 }
 
-func (g *testGame) Draw(screen *ebiten.Image)  { g.game.Draw(screen) }
-func (g *testGame) Layout(w, h int) (int, int) { return g.game.Layout(w, h) }
+func TestReplay(t *testing.T) {
+	var recording bytes.Buffer
+	h := game.NewHeadless(7, false, nil)
+	h.Input.Hold(30, 200, utils.KeyRight)
+	h.Input.Hold(60, 70, utils.KeyJump)
+	h.Input.Press(150, utils.KeyGuard)
+	var err error
+	if utils.Recorder, err = utils.NewInputRecorder(&recording, 7); err != nil {
+		t.Fatal(err)
+	}
+	err = h.Run(frames / 10)
+	if closeErr := utils.Recorder.Close(); closeErr != nil {
+		t.Fatal(closeErr)
+	}
+	utils.Recorder = nil
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, y := vars.Player.Position()
 
-func TestMain(_ *testing.M) {
-	if err := ebiten.RunGame(&testGame{game: &game.Game{}, frames: 10}); err != nil {
-		if errors.Is(err, ebiten.Termination) {
-			os.Exit(0)
-		}
-		log.Fatal(err)
+	script, seed, err := utils.ReadInputScript(&recording)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay := game.NewHeadless(seed, false, nil)
+	replay.Input = script
+	if err := replay.Run(frames / 10); err != nil {
+		t.Fatal(err)
+	}
+	if rx, ry := vars.Player.Position(); rx != x || ry != y {
+		t.Errorf("replay diverged from recording: %v,%v != %v,%v", rx, ry, x, y)
 	}
 }
@@ -30,18 +30,45 @@ type Comp struct {
 	core.Entity
 	Target      core.Entity
 	act         func()
+	tree        *Tree
 	actionQueue []actionItem
 	DebugRect   *bump.Rect
+	// Memory is how many seconds the target is chased once out of sight, DefaultMemory when 0.
+	Memory       float64
+	lastX, lastY float64
+	seen         bool
+	forgetTimer  float64
 }
 
 func (c *Comp) Init(entity core.Entity) {
 	c.Entity = entity
 }
 
-func (c *Comp) Remove() {}
+func (c *Comp) Remove() {
+	if c.tree != nil {
+		c.tree.Abort()
+	}
+}
+
+// Reset forgets the target and drops the running actions.
+func (c *Comp) Reset() {
+	if c.tree != nil {
+		c.tree.Abort()
+	}
+	if len(c.actionQueue) > 0 && c.actionQueue[0].action.Exit != nil {
+		c.actionQueue[0].action.Exit()
+	}
+	c.actionQueue = nil
+	c.Target = nil
+	c.seen = false
+	c.forgetTimer = 0
+}
 
 func (c *Comp) SetAct(act func()) { c.act = act }
 
+// SetTree makes the behavior tree drive the entity, ticking it every update instead of the action queue.
+func (c *Comp) SetTree(tree *Tree) { c.tree = tree }
+
 func (c *Comp) Add(timeout float64, action *Action) {
 	if timeout <= 0 {
 		timeout = math.MaxFloat64
@@ -61,6 +88,12 @@ func (c *Comp) Update(dt float64) {
 			c.Target = nil
 		}
 	}
+	c.updatePerception(dt)
+	if c.tree != nil {
+		c.tree.Tick(dt)
+
+		return
+	}
 	if len(c.actionQueue) == 0 {
 		if c.act != nil {
 			c.act()
@@ -84,13 +117,25 @@ func (c *Comp) Update(dt float64) {
 }
 
 func (c *Comp) Draw(pipeline *core.Pipeline, entityPos ebiten.GeoM) {
-	if !DebugDraw || len(c.actionQueue) == 0 {
+	if !DebugDraw {
 		return
 	}
+	var name string
+	switch {
+	case c.tree != nil:
+		name = c.tree.Path()
+	case len(c.actionQueue) > 0:
+		name = c.actionQueue[0].action.Name
+	default:
+		return
+	}
+	if c.TargetLost() {
+		name += "?"
+	}
 	op := &ebiten.DrawImageOptions{GeoM: entityPos}
 	op.GeoM.Translate(-5, -10)
 	pipeline.Add(vars.PipelineScreenTag, vars.PipelineUILayer, func(screen *ebiten.Image) {
-		utils.DrawText(screen, "AI:"+c.actionQueue[0].action.Name, assets.NanoFont, op)
+		utils.DrawText(screen, "AI:"+name, assets.NanoFont, op)
 		if c.DebugRect != nil {
 			image := ebiten.NewImage(int(c.DebugRect.W), int(c.DebugRect.H))
 			image.Fill(color.NRGBA{255, 255, 0, 75})
@@ -108,7 +153,7 @@ func (c *Comp) InTargetRange(minDist, maxDist float64) bool {
 	}
 
 	x, y := c.Position()
-	tx, ty := c.Target.Position()
+	tx, ty := c.TargetPosition()
 	dist := utils.Distante(x, y, tx, ty)
 
 	in := dist >= minDist
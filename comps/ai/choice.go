@@ -1,6 +1,6 @@
 package ai
 
-import "math/rand"
+import "game/utils"
 
 type Choice = struct {
 	Weight float64
@@ -15,7 +15,7 @@ func (c Choices) Play() {
 		totalWeight += c.Weight
 	}
 
-	r := rand.Float64() * totalWeight
+	r := utils.Rand.Float64() * totalWeight
 	for _, c := range c {
 		if r -= c.Weight; r <= 0 {
 			c.Act()
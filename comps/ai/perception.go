@@ -0,0 +1,99 @@
+package ai
+
+import (
+	"game/comps/faction"
+	"game/core"
+	"game/libs/bump"
+	"game/utils"
+	"game/vars"
+)
+
+var (
+	DefaultMemory = 5.0
+	eyeHeight     = 0.25 // From the top of the entity, in its height.
+)
+
+// MakeNoise wakes every ai within radius of source, when source is one of their targets.
+func MakeNoise(source core.Entity, radius float64) {
+	sx, sy := center(source)
+	area := bump.NewRect(sx-radius, sy-radius, radius*2, radius*2)
+	for _, col := range vars.World.Space.Query(area, nil, "body") {
+		entity, ok := col.Other.(core.Entity)
+		if !ok || entity == source || !faction.Hostile(entity, source) {
+			continue
+		}
+		if c := core.Get[*Comp](entity); c != nil {
+			if x, y := center(entity); utils.Distante(x, y, sx, sy) <= radius {
+				c.hear(source)
+			}
+		}
+	}
+}
+
+// LineOfSight reports whether no wall, door or other solid is between the two points.
+func LineOfSight(x1, y1, x2, y2 float64) bool {
+	space := vars.World.Space
+	walls := space.QuerySegment(bump.Vec2{X: x1, Y: y1}, bump.Vec2{X: x2, Y: y2}, func(item bump.Item) bool {
+		return !space.Has(item, "slope") && !space.Has(item, "passthrough")
+	}, "map", "solid")
+
+	return len(walls) == 0
+}
+
+// Sees reports whether target is in the line of sight of the entity eyes.
+func (c *Comp) Sees(target core.Entity) bool {
+	x, y, w, h := c.Rect()
+	tx, ty := center(target)
+
+	return LineOfSight(x+w/2, y+h*eyeHeight, tx, ty)
+}
+
+// Notice makes target the current one, knowing exactly where it is.
+func (c *Comp) Notice(target core.Entity) {
+	c.Target, c.seen, c.forgetTimer = target, true, c.memory()
+	c.lastX, c.lastY = target.Position()
+}
+
+// TargetPosition is the position the target was last seen or heard at.
+func (c *Comp) TargetPosition() (float64, float64) { return c.lastX, c.lastY }
+
+// TargetLost reports whether the target is out of sight, the ai only knowing its last position.
+func (c *Comp) TargetLost() bool { return c.Target != nil && !c.seen }
+
+func (c *Comp) hear(source core.Entity) {
+	if c.Target != nil && c.seen {
+		return
+	}
+	c.Target, c.seen, c.forgetTimer = source, false, c.memory()
+	c.lastX, c.lastY = source.Position()
+}
+
+// updatePerception follows the target while it's in sight, giving up once it's been lost for longer than the memory.
+func (c *Comp) updatePerception(dt float64) {
+	if c.Target == nil {
+		return
+	}
+	if c.seen = c.Sees(c.Target); c.seen {
+		c.lastX, c.lastY = c.Target.Position()
+		c.forgetTimer = c.memory()
+
+		return
+	}
+	if c.forgetTimer -= dt; c.forgetTimer <= 0 {
+		c.Target = nil
+	}
+}
+
+func (c *Comp) memory() float64 {
+	if c.Memory == 0 {
+		return DefaultMemory
+	}
+
+	return c.Memory
+}
+
+func center(entity core.Entity) (float64, float64) {
+	x, y, w, h := entity.Rect()
+
+	return x + w/2, y + h/2
+}
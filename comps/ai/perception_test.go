@@ -0,0 +1,61 @@
+package ai_test
+
+import (
+	"game/comps/ai"
+	"game/core/coretest"
+	"game/libs/bump"
+	"game/vars"
+	"testing"
+)
+
+func TestLineOfSight(t *testing.T) {
+	coretest.NewWorld()
+	if !ai.LineOfSight(10, 50, 200, 50) {
+		t.Error("line of sight blocked with nothing in the way")
+	}
+
+	wall := bump.Rect{X: 100, Y: 0, W: 16, H: 100}
+	vars.World.Space.Set(&wall, wall, "map")
+	if ai.LineOfSight(10, 50, 200, 50) {
+		t.Error("line of sight through a wall")
+	}
+	if !ai.LineOfSight(10, 150, 200, 150) {
+		t.Error("line of sight blocked under the wall")
+	}
+
+	platform := bump.Rect{X: 0, Y: 120, W: 200, H: 4}
+	vars.World.Space.Set(&platform, platform, "map", "passthrough")
+	if !ai.LineOfSight(50, 100, 50, 150) {
+		t.Error("line of sight blocked by a passthrough platform")
+	}
+}
+
+func TestMemory(t *testing.T) {
+	coretest.NewWorld()
+	watcher, target := coretest.NewEntity(10, 40, 8, 16), coretest.NewEntity(60, 40, 8, 16)
+	c := &ai.Comp{Memory: 2}
+	c.Init(watcher)
+	c.Notice(target)
+	c.Update(0.5)
+	if c.Target != target || c.TargetLost() {
+		t.Fatal("target in sight not followed")
+	}
+
+	// Behind a wall it's only known where it was last seen.
+	wall := bump.Rect{X: 40, Y: 0, W: 8, H: 100}
+	vars.World.Space.Set(&wall, wall, "map")
+	target.SetPosition(200, 40)
+	for range 3 {
+		c.Update(0.5)
+	}
+	if c.Target != target || !c.TargetLost() {
+		t.Fatal("target forgotten before the memory ran out")
+	}
+	if x, y := c.TargetPosition(); x != 60 || y != 40 {
+		t.Errorf("target position %v,%v, want where it was last seen 60,40", x, y)
+	}
+	c.Update(0.5)
+	if c.Target != nil {
+		t.Error("target still chased once the memory ran out")
+	}
+}
@@ -0,0 +1,367 @@
+package ai
+
+import (
+	"game/utils"
+	"math"
+	"strings"
+)
+
+type Status int
+
+const (
+	Running Status = iota
+	Success
+	Failure
+)
+
+// Node is a behavior tree node. Nodes reset themselves once they stop running, Abort stops a running one early.
+type Node interface {
+	Tick(tree *Tree, dt float64) Status
+	Abort()
+}
+
+// Tree runs a behavior tree in place of the action queue, see Comp.SetTree.
+type Tree struct {
+	Root Node
+	Time float64
+	path []string
+}
+
+func NewTree(root Node) *Tree { return &Tree{Root: root} }
+
+func (t *Tree) Tick(dt float64) Status {
+	t.Time += dt
+	t.path = t.path[:0]
+
+	return t.tickChild(t.Root, dt)
+}
+
+func (t *Tree) Abort() {
+	t.Root.Abort()
+	t.path = t.path[:0]
+}
+
+// Path is the names of the nodes still running after the last tick, from the root down.
+func (t *Tree) Path() string { return strings.Join(t.path, ">") }
+
+// tickChild ticks a child node, keeping its path only when it's still running.
+func (t *Tree) tickChild(node Node, dt float64) Status {
+	depth := len(t.path)
+	status := node.Tick(t, dt)
+	if status != Running {
+		t.path = t.path[:depth]
+	}
+
+	return status
+}
+
+func (t *Tree) enter(name string) {
+	if name != "" {
+		t.path = append(t.path, name)
+	}
+}
+
+type leaf struct {
+	action  *Action
+	timeout float64
+	timer   float64
+	started bool
+}
+
+// Do runs an Action until its Next returns true or the timeout ends, always succeeding.
+// A timeout of 0 never ends, like in Comp.Add.
+func Do(timeout float64, action *Action) Node {
+	if timeout <= 0 {
+		timeout = math.MaxFloat64
+	}
+	if action.Next == nil {
+		action.Next = func(_ float64) bool { return false }
+	}
+
+	return &leaf{action: action, timeout: timeout}
+}
+
+func (l *leaf) Tick(tree *Tree, dt float64) Status {
+	tree.enter(l.action.Name)
+	if !l.started {
+		l.started, l.timer = true, l.timeout
+		if l.action.Entry != nil {
+			l.action.Entry()
+		}
+	}
+	l.timer -= dt
+	if l.timer <= 0 || l.action.Next(dt) {
+		l.Abort()
+
+		return Success
+	}
+
+	return Running
+}
+
+func (l *leaf) Abort() {
+	if l.started && l.action.Exit != nil {
+		l.action.Exit()
+	}
+	l.started = false
+}
+
+type condition struct {
+	name string
+	test func() bool
+}
+
+// Condition succeeds when test is true, failing otherwise.
+func Condition(name string, test func() bool) Node { return &condition{name, test} }
+
+func (c *condition) Tick(tree *Tree, _ float64) Status {
+	tree.enter(c.name)
+	if c.test() {
+		return Success
+	}
+
+	return Failure
+}
+
+func (c *condition) Abort() {}
+
+type sequence struct {
+	children []Node
+	current  int
+}
+
+// Sequence runs its children in order, failing as soon as one of them fails.
+func Sequence(children ...Node) Node { return &sequence{children: children} }
+
+func (s *sequence) Tick(tree *Tree, dt float64) Status {
+	tree.enter("Sequence")
+	for s.current < len(s.children) {
+		switch tree.tickChild(s.children[s.current], dt) {
+		case Running:
+			return Running
+		case Failure:
+			s.current = 0
+
+			return Failure
+		case Success:
+			s.current++
+		}
+	}
+	s.current = 0
+
+	return Success
+}
+
+func (s *sequence) Abort() {
+	if s.current < len(s.children) {
+		s.children[s.current].Abort()
+	}
+	s.current = 0
+}
+
+type selector struct {
+	children []Node
+	current  int
+}
+
+// Selector runs its children in order until one of them doesn't fail.
+func Selector(children ...Node) Node { return &selector{children: children} }
+
+func (s *selector) Tick(tree *Tree, dt float64) Status {
+	tree.enter("Selector")
+	for s.current < len(s.children) {
+		switch tree.tickChild(s.children[s.current], dt) {
+		case Running:
+			return Running
+		case Success:
+			s.current = 0
+
+			return Success
+		case Failure:
+			s.current++
+		}
+	}
+	s.current = 0
+
+	return Failure
+}
+
+func (s *selector) Abort() {
+	if s.current < len(s.children) {
+		s.children[s.current].Abort()
+	}
+	s.current = 0
+}
+
+type parallel struct {
+	children []Node
+	done     []bool
+}
+
+// Parallel ticks all its children together, succeeding when all of them succeed and failing when any of them fails.
+func Parallel(children ...Node) Node {
+	return &parallel{children: children, done: make([]bool, len(children))}
+}
+
+func (p *parallel) Tick(tree *Tree, dt float64) Status {
+	tree.enter("Parallel")
+	depth := len(tree.path)
+	running := false
+	for i, child := range p.children {
+		if p.done[i] {
+			continue
+		}
+		childDepth := len(tree.path)
+		switch tree.tickChild(child, dt) {
+		case Running:
+			// Only the first running child shows in the path.
+			if running {
+				tree.path = tree.path[:childDepth]
+			}
+			running = true
+		case Failure:
+			tree.path = tree.path[:depth]
+			p.Abort()
+
+			return Failure
+		case Success:
+			p.done[i] = true
+		}
+	}
+	if running {
+		return Running
+	}
+	clear(p.done)
+
+	return Success
+}
+
+func (p *parallel) Abort() {
+	for i, child := range p.children {
+		if !p.done[i] {
+			child.Abort()
+		}
+	}
+	clear(p.done)
+}
+
+type decorator struct {
+	name  string
+	child Node
+	tick  func(tree *Tree, child Node, dt float64) Status
+}
+
+// Decorator wraps child, tick deciding when and how it runs.
+func Decorator(name string, child Node, tick func(tree *Tree, child Node, dt float64) Status) Node {
+	return &decorator{name, child, tick}
+}
+
+func (d *decorator) Tick(tree *Tree, dt float64) Status {
+	tree.enter(d.name)
+
+	return d.tick(tree, d.child, dt)
+}
+
+func (d *decorator) Abort() { d.child.Abort() }
+
+// Invert turns the success of child into a failure and the other way around.
+func Invert(child Node) Node {
+	return Decorator("Invert", child, func(tree *Tree, child Node, dt float64) Status {
+		switch status := tree.tickChild(child, dt); status {
+		case Success:
+			return Failure
+		case Failure:
+			return Success
+		default:
+			return status
+		}
+	})
+}
+
+// While runs child as long as test is true, aborting it and failing otherwise.
+func While(test func() bool, child Node) Node {
+	return Decorator("While", child, func(tree *Tree, child Node, dt float64) Status {
+		if !test() {
+			child.Abort()
+
+			return Failure
+		}
+
+		return tree.tickChild(child, dt)
+	})
+}
+
+// Repeat runs child again every time it succeeds, until it fails.
+func Repeat(child Node) Node {
+	return Decorator("Repeat", child, func(tree *Tree, child Node, dt float64) Status {
+		if tree.tickChild(child, dt) == Failure {
+			return Failure
+		}
+
+		return Running
+	})
+}
+
+// Cooldown fails for the given seconds after child stops running.
+func Cooldown(seconds float64, child Node) Node {
+	readyAt := math.Inf(-1)
+
+	return Decorator("Cooldown", child, func(tree *Tree, child Node, dt float64) Status {
+		if tree.Time < readyAt {
+			return Failure
+		}
+		status := tree.tickChild(child, dt)
+		if status != Running {
+			readyAt = tree.Time + seconds
+		}
+
+		return status
+	})
+}
+
+type Weighted = struct {
+	Weight float64
+	Node   Node
+}
+
+type random struct {
+	choices []Weighted
+	current Node
+}
+
+// Random runs one of its children picked by weight, like Choices.
+func Random(choices ...Weighted) Node { return &random{choices: choices} }
+
+func (r *random) Tick(tree *Tree, dt float64) Status {
+	tree.enter("Random")
+	if r.current == nil {
+		totalWeight := 0.0
+		for _, choice := range r.choices {
+			totalWeight += choice.Weight
+		}
+		pick := utils.Rand.Float64() * totalWeight
+		for _, choice := range r.choices {
+			if pick -= choice.Weight; pick <= 0 {
+				r.current = choice.Node
+
+				break
+			}
+		}
+		if r.current == nil {
+			return Failure
+		}
+	}
+	status := tree.tickChild(r.current, dt)
+	if status != Running {
+		r.current = nil
+	}
+
+	return status
+}
+
+func (r *random) Abort() {
+	if r.current != nil {
+		r.current.Abort()
+	}
+	r.current = nil
+}
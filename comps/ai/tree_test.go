@@ -0,0 +1,87 @@
+package ai_test
+
+import (
+	"game/comps/ai"
+	"testing"
+)
+
+// countedAction runs for the given ticks, counting its entries and exits.
+type countedAction struct {
+	ticks, entries, exits int
+}
+
+func (c *countedAction) action(name string) *ai.Action {
+	ticks := 0
+
+	return &ai.Action{
+		Name:  name,
+		Entry: func() { c.entries++; ticks = 0 },
+		Next:  func(_ float64) bool { ticks++; return ticks >= c.ticks },
+		Exit:  func() { c.exits++ },
+	}
+}
+
+func TestTreeSequence(t *testing.T) {
+	walk := &countedAction{ticks: 2}
+	tree := ai.NewTree(ai.Selector(
+		ai.Condition("Far", func() bool { return false }),
+		ai.Sequence(ai.Condition("Near", func() bool { return true }), ai.Do(0, walk.action("Walk"))),
+	))
+
+	if status := tree.Tick(0.1); status != ai.Running || tree.Path() != "Selector>Sequence>Walk" {
+		t.Errorf("first tick = %v on %q, want running on Selector>Sequence>Walk", status, tree.Path())
+	}
+	if status := tree.Tick(0.1); status != ai.Success || tree.Path() != "" {
+		t.Errorf("second tick = %v on %q, want a success with an empty path", status, tree.Path())
+	}
+	if walk.entries != 1 || walk.exits != 1 {
+		t.Errorf("action entered %d and exited %d times, want once each", walk.entries, walk.exits)
+	}
+}
+
+func TestTreeAbort(t *testing.T) {
+	guard := &countedAction{ticks: 10}
+	hold := &countedAction{ticks: 10}
+	keepGuarding := true
+	tree := ai.NewTree(ai.Parallel(
+		ai.While(func() bool { return keepGuarding }, ai.Do(0, guard.action("Guard"))),
+		ai.Do(0, hold.action("Hold")),
+	))
+
+	if status := tree.Tick(0.1); status != ai.Running || tree.Path() != "Parallel>While>Guard" {
+		t.Errorf("tick = %v on %q, want running on Parallel>While>Guard", status, tree.Path())
+	}
+	keepGuarding = false
+	if status := tree.Tick(0.1); status != ai.Failure {
+		t.Errorf("tick once the While test fails = %v, want a failure", status)
+	}
+	if guard.exits != 1 || hold.exits != 1 {
+		t.Errorf("actions exited %d and %d times, want both aborted once", guard.exits, hold.exits)
+	}
+}
+
+func TestTreeCooldown(t *testing.T) {
+	runs := 0
+	tree := ai.NewTree(ai.Cooldown(1, ai.Condition("Ready", func() bool { runs++; return true })))
+
+	var statuses []ai.Status
+	for range 4 {
+		statuses = append(statuses, tree.Tick(0.4))
+	}
+	want := []ai.Status{ai.Success, ai.Failure, ai.Failure, ai.Success}
+	for i := range want {
+		if statuses[i] != want[i] {
+			t.Fatalf("statuses = %v, want %v", statuses, want)
+		}
+	}
+	if runs != 2 {
+		t.Errorf("child ran %d times, want 2", runs)
+	}
+}
+
+func TestTreeInvert(t *testing.T) {
+	tree := ai.NewTree(ai.Repeat(ai.Invert(ai.Condition("Hit", func() bool { return true }))))
+	if status := tree.Tick(0.1); status != ai.Failure {
+		t.Errorf("repeat of an inverted success = %v, want a failure", status)
+	}
+}
@@ -40,6 +40,7 @@ type Comp struct {
 	Layer          int
 	ColorScale     color.Color
 	Fsm            *Fsm
+	Sounds         map[string]map[int]string // Sound names by tag and frame, played each time the frame shows up.
 
 	State          string
 	Image          *ebiten.Image
@@ -49,9 +50,12 @@ type Comp struct {
 	stateEffect    *stateEffect
 	sliceCallback  func()
 	frameCallbacks map[int]func()
+	entity         core.Entity
+	soundFrame     int
 }
 
-func (c *Comp) Init(_ core.Entity) {
+func (c *Comp) Init(entity core.Entity) {
+	c.entity = entity
 	var err error
 	if c.Image, _, err = ebitenutil.NewImageFromFileSystem(assets.FS, c.FilesName+".png"); err != nil {
 		log.Panic(err)
@@ -81,6 +85,8 @@ func (c *Comp) Init(_ core.Entity) {
 
 func (c *Comp) Remove() {}
 
+func (c *Comp) Reset() { c.SetState(vars.IdleTag) }
+
 func (c *Comp) SetState(state string) {
 	if c.State == state {
 		return
@@ -97,6 +103,7 @@ func (c *Comp) SetState(state string) {
 	}
 	c.sliceCallback = nil
 	c.frameCallbacks = map[int]func(){}
+	c.soundFrame = -1
 }
 
 func (c *Comp) Update(dt float64) {
@@ -111,6 +118,7 @@ func (c *Comp) Update(dt float64) {
 		}
 	}
 	currentAnimFrame := c.Data.CurrentFrame - c.Data.CurrentAnimation.From
+	c.playFrameSound(currentAnimFrame)
 	if frameCallback := c.frameCallbacks[currentAnimFrame]; frameCallback != nil {
 		frameCallback()
 		delete(c.frameCallbacks, currentAnimFrame)
@@ -165,6 +173,17 @@ func (c *Comp) OnSlicePresent(sliceName string, callback SliceCallback) {
 
 func (c *Comp) OnFrame(frame int, callback func()) { c.frameCallbacks[frame] = callback }
 
+func (c *Comp) playFrameSound(frame int) {
+	if frame == c.soundFrame {
+		return
+	}
+	c.soundFrame = frame
+	if name, ok := c.Sounds[c.State][frame]; ok {
+		x, y, w, h := c.entity.Rect()
+		vars.Audio.PlayAt(name, x+w/2, y+h/2)
+	}
+}
+
 func (c *Comp) FrameSlice(sliceName string) (bump.Rect, error) {
 	keys := c.slices[sliceName]
 	if keys == nil {
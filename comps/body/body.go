@@ -23,6 +23,8 @@ type Comp struct {
 	Vx, Vy                        float64
 	MaxX, MaxY                    float64
 	Weight                        float64
+	SpeedMult                     float64
+	LandSpeed                     float64 // The falling speed on the frame it touched ground, 0 otherwise.
 	Tags, QueryTags               []bump.Tag
 	FilterOut                     []core.Entity
 	entity                        core.Entity
@@ -55,6 +57,12 @@ func (c *Comp) Init(entity core.Entity) {
 
 func (c *Comp) Remove() { c.space.Remove(c.entity) }
 
+// Reset stops the body where the entity was moved to, without colliding on the way.
+func (c *Comp) Reset() {
+	c.Vx, c.Vy, c.prevVx = 0, 0, 0
+	c.space.Set(c.entity, bump.NewRect(c.entity.Rect()))
+}
+
 func (c *Comp) Update(dt float64) {
 	if c.NoUpdate {
 		return
@@ -119,10 +127,15 @@ func (c *Comp) updateMovement(dt float64, noForceApplied bool) {
 	c.Vy = math.Min(c.MaxY, math.Max(-c.MaxY, c.Vy))
 
 	ex, ey := c.entity.Position()
-	t := bump.Vec2{X: ex + c.Vx*dt, Y: ey + c.Vy*dt}
+	speedMult := 1.0
+	if c.SpeedMult != 0 {
+		speedMult = c.SpeedMult
+	}
+	t := bump.Vec2{X: ex + c.Vx*speedMult*dt, Y: ey + c.Vy*dt}
 	goal, cols := c.space.Move(c.entity, t, c.bodyFilter(), c.QueryTags...)
 	c.entity.SetPosition(goal.X, goal.Y)
 
+	wasGround, fallSpeed := c.Ground, c.Vy
 	c.Ground = false
 	c.InsidePassThrough = false
 	for _, col := range cols {
@@ -143,6 +156,10 @@ func (c *Comp) updateMovement(dt float64, noForceApplied bool) {
 		}
 		c.InsidePassThrough = c.InsidePassThrough || (c.space.Has(col.Other, "passthrough") && col.Overlaps)
 	}
+	c.LandSpeed = 0
+	if c.Ground && !wasGround {
+		c.LandSpeed = fallSpeed
+	}
 	if c.Ground {
 		c.coyoteTime = vars.CoyoteTimeSeconds
 		if c.QueryFloor("slope") {
@@ -0,0 +1,192 @@
+package boss
+
+import (
+	"encoding/json"
+	"game/assets"
+	"game/comps/ai"
+	"game/comps/gated"
+	"game/comps/stats"
+	"game/core"
+	"game/utils"
+	"game/vars"
+	"image/color"
+	"log"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	barMarginX = 20
+	barY       = vars.ScreenHeight - 8
+	barW       = vars.ScreenWidth - barMarginX*2
+	barInnerH  = 3
+)
+
+var (
+	barBorderColor = color.RGBA{34, 32, 52, 255}
+	barEmptyColor  = color.RGBA{89, 86, 82, 255}
+	barLagColor    = color.RGBA{251, 242, 54, 255}
+	barHealthColor = color.RGBA{172, 50, 50, 255}
+	barImage       = ebiten.NewImage(1, 1)
+)
+
+func init() { barImage.Fill(color.White) }
+
+// Phase is a part of the fight, lasting from its health threshold down to the next phase one.
+type Phase struct {
+	Threshold float64 // Fraction of the max health the phase starts at.
+	Enter     func()
+	Choices   ai.Choices
+}
+
+// Comp turns its entity into a boss: Start locks the arena gates and shows the health bar, defeating it opens them
+// for good. The phases are expected in decreasing threshold order, the first one starting the fight.
+type Comp struct {
+	Name     string
+	Phases   []Phase
+	Defeated bool
+	entity   core.Entity
+	stats    *stats.Comp
+	gates    *gated.Comp
+	phase    int
+	fighting bool
+}
+
+type state struct {
+	Defeated bool `json:"defeated"`
+}
+
+func (c *Comp) Init(entity core.Entity) {
+	c.entity = entity
+	c.stats = core.Get[*stats.Comp](entity)
+	c.gates = core.Get[*gated.Comp](entity)
+	if c.stats == nil {
+		log.Panicf("boss: %s has no stats comp", c.Name)
+	}
+	c.stats.NoHeadBar = true
+}
+
+func (c *Comp) Remove() {}
+
+func (c *Comp) Update(_ float64) {
+	if c.Defeated {
+		return
+	}
+	if c.stats.Health <= 0 {
+		c.Defeated, c.fighting = true, false
+		c.openGates()
+
+		return
+	}
+	if !c.fighting {
+		return
+	}
+	for c.phase+1 < len(c.Phases) && c.stats.HealthPercent() <= c.Phases[c.phase+1].Threshold {
+		c.phase++
+		if enter := c.Phases[c.phase].Enter; enter != nil {
+			enter()
+		}
+	}
+}
+
+func (c *Comp) Draw(_ *core.Pipeline, _ ebiten.GeoM) {}
+
+// Fighting returns the boss in a fight in world, nil when there's none.
+func Fighting(world *core.World) *Comp {
+	for _, c := range core.Each[*Comp](world) {
+		if c.fighting {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// DrawBar draws the health bar of the boss fighting, from the scene so it stays while the boss is off-screen.
+func DrawBar(pipeline *core.Pipeline) {
+	c := Fighting(vars.World)
+	if c == nil {
+		return
+	}
+	health := math.Round(c.stats.HealthPercent() * barW)
+	lag := math.Round(c.stats.HealthLag() / c.stats.MaxHealth * barW)
+	pipeline.Add(vars.PipelineScreenTag, vars.PipelineUILayer, func(screen *ebiten.Image) {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(barMarginX, barY-7)
+		utils.DrawText(screen, c.Name, assets.NanoFont, op)
+
+		drawBar(screen, barMarginX-1, barY-1, barW+2, barInnerH+2, barBorderColor)
+		drawBar(screen, barMarginX, barY, barW, barInnerH, barEmptyColor)
+		drawBar(screen, barMarginX, barY, lag, barInnerH, barLagColor)
+		drawBar(screen, barMarginX, barY, health, barInnerH, barHealthColor)
+	})
+	pipeline.Add(vars.PipelineNormalMapTag, vars.PipelineUILayer, func(normalMap *ebiten.Image) {
+		op := &ebiten.DrawImageOptions{Blend: ebiten.BlendDestinationOut}
+		op.GeoM.Scale(barW+2, barInnerH+2)
+		op.GeoM.Translate(barMarginX-1, barY-1)
+		normalMap.DrawImage(barImage, op)
+	})
+}
+
+// Start begins the fight, if the boss isn't already fighting or defeated.
+func (c *Comp) Start() {
+	if c.fighting || c.Defeated {
+		return
+	}
+	c.fighting = true
+	if c.gates != nil {
+		c.gates.Close()
+	}
+	if len(c.Phases) > 0 && c.Phases[0].Enter != nil {
+		c.Phases[0].Enter()
+	}
+}
+
+// Play queues one of the current phase choices.
+func (c *Comp) Play() {
+	if len(c.Phases) > 0 {
+		c.Phases[c.phase].Choices.Play()
+	}
+}
+
+func (c *Comp) Phase() int { return c.phase }
+
+func (c *Comp) SaveState() any {
+	if !c.Defeated {
+		return nil
+	}
+
+	return state{Defeated: true}
+}
+
+func (c *Comp) LoadState(data json.RawMessage) error {
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s.Defeated {
+		c.Defeated = true
+		c.openGates()
+		vars.World.Remove(c.entity)
+	}
+
+	return nil
+}
+
+func (c *Comp) openGates() {
+	if c.gates != nil {
+		c.gates.Open()
+	}
+}
+
+func drawBar(screen *ebiten.Image, x, y, w, h float64, barColor color.Color) {
+	if w <= 0 {
+		return
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(w, h)
+	op.GeoM.Translate(x, y)
+	op.ColorScale.ScaleWithColor(barColor)
+	screen.DrawImage(barImage, op)
+}
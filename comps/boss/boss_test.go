@@ -0,0 +1,79 @@
+package boss_test
+
+import (
+	"game/comps/boss"
+	"game/comps/stats"
+	"game/core"
+	"game/core/coretest"
+	"game/vars"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// newBoss adds a boss with three phases far off-screen, recording the phases entered.
+func newBoss(t *testing.T) (*boss.Comp, *stats.Comp, *[]int) {
+	t.Helper()
+	coretest.NewWorld()
+	entered := &[]int{}
+	enter := func(phase int) func() { return func() { *entered = append(*entered, phase) } }
+	bossComp := &boss.Comp{Name: "Test", Phases: []boss.Phase{
+		{Threshold: 1, Enter: enter(0)}, {Threshold: 0.75, Enter: enter(1)}, {Threshold: 0.5, Enter: enter(2)},
+	}}
+	bossStats := &stats.Comp{MaxHealth: 100}
+	e := coretest.NewEntity(5000, 5000, 16, 16)
+	e.Add(bossStats, bossComp)
+	vars.World.Add(e)
+	vars.World.Update(0)
+
+	return bossComp, bossStats, entered
+}
+
+func TestPhases(t *testing.T) {
+	bossComp, bossStats, entered := newBoss(t)
+	bossStats.Health = 80
+	bossComp.Update(0)
+	if len(*entered) != 0 {
+		t.Fatalf("phases %v entered before the fight started", *entered)
+	}
+
+	bossComp.Start()
+	bossComp.Start()
+	bossComp.Update(0)
+	if !slices.Equal(*entered, []int{0}) || bossComp.Phase() != 0 {
+		t.Errorf("phases %v entered on phase %d at 80%%, want only the first", *entered, bossComp.Phase())
+	}
+	bossStats.Health = 40
+	bossComp.Update(0)
+	if !slices.Equal(*entered, []int{0, 1, 2}) || bossComp.Phase() != 2 {
+		t.Errorf("phases %v entered on phase %d at 40%%, want every one in order", *entered, bossComp.Phase())
+	}
+
+	bossStats.Health = 0
+	bossComp.Update(0)
+	if !bossComp.Defeated {
+		t.Error("boss not defeated without health")
+	}
+}
+
+func TestBar(t *testing.T) {
+	bossComp, bossStats, _ := newBoss(t)
+	if boss.Fighting(vars.World) != nil {
+		t.Fatal("boss fighting before the fight started")
+	}
+	bossComp.Start()
+	// The boss stays far off-screen, its bar shown all along the fight.
+	if boss.Fighting(vars.World) != bossComp {
+		t.Fatal("off-screen boss not fighting once the fight started")
+	}
+	pipeline := core.NewPipeline()
+	boss.DrawBar(pipeline)
+	pipeline.Compose(vars.PipelineScreenTag, ebiten.NewImage(vars.ScreenWidth, vars.ScreenHeight))
+
+	bossStats.Health = 0
+	bossComp.Update(0)
+	if boss.Fighting(vars.World) != nil {
+		t.Error("boss still fighting once defeated, its bar shown")
+	}
+}
@@ -0,0 +1,130 @@
+package faction
+
+import (
+	"encoding/json"
+	"fmt"
+	"game/core"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type Faction string
+
+const (
+	None     Faction = ""
+	Player   Faction = "player"
+	Monsters Faction = "monsters"
+)
+
+type Relation int
+
+const (
+	Neutral Relation = iota // Hit each other but don't go after each other.
+	Enemies
+	Allies // Don't hit each other.
+)
+
+var relationNames = map[string]Relation{"neutral": Neutral, "enemies": Enemies, "allies": Allies}
+
+func (r *Relation) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	relation, ok := relationNames[name]
+	if !ok {
+		return fmt.Errorf("faction: unknown relation %q", name)
+	}
+	*r = relation
+
+	return nil
+}
+
+// Matrix holds how factions see each other, the same both ways. A faction is allied with itself unless set
+// otherwise, and neutral with the rest.
+type Matrix struct {
+	relations map[[2]Faction]Relation
+}
+
+// Relations is the matrix every entity follows.
+var Relations = NewMatrix()
+
+func NewMatrix() *Matrix {
+	m := &Matrix{relations: map[[2]Faction]Relation{}}
+	m.Set(Player, Monsters, Enemies)
+
+	return m
+}
+
+func (m *Matrix) Set(a, b Faction, relation Relation) {
+	m.relations[[2]Faction{a, b}] = relation
+	m.relations[[2]Faction{b, a}] = relation
+}
+
+// UnmarshalJSON sets the relations of a list of {"factions": [a, b], "relation": name}, keeping the ones not in it.
+func (m *Matrix) UnmarshalJSON(data []byte) error {
+	var relations []struct {
+		Factions [2]Faction `json:"factions"`
+		Relation Relation   `json:"relation"`
+	}
+	if err := json.Unmarshal(data, &relations); err != nil {
+		return err
+	}
+	for _, r := range relations {
+		if r.Factions[0] == None || r.Factions[1] == None {
+			return fmt.Errorf("faction: relation %v without its two factions", r.Factions)
+		}
+		m.Set(r.Factions[0], r.Factions[1], r.Relation)
+	}
+
+	return nil
+}
+
+func (m *Matrix) Get(a, b Faction) Relation {
+	if a == None || b == None {
+		return Neutral
+	}
+	if relation, ok := m.relations[[2]Faction{a, b}]; ok {
+		return relation
+	}
+	if a == b {
+		return Allies
+	}
+
+	return Neutral
+}
+
+// Comp puts an entity in a faction, the ones without it being in None.
+type Comp struct {
+	Faction Faction
+}
+
+// FromProps is the faction of the faction property of a Tiled object, or fallback without one.
+func FromProps(props map[string]string, fallback Faction) *Comp {
+	if faction := props["faction"]; faction != "" {
+		return &Comp{Faction: Faction(faction)}
+	}
+
+	return &Comp{Faction: fallback}
+}
+
+func (c *Comp) Init(_ core.Entity)                   {}
+func (c *Comp) Update(_ float64)                     {}
+func (c *Comp) Draw(_ *core.Pipeline, _ ebiten.GeoM) {}
+func (c *Comp) Remove()                              {}
+
+func Of(entity core.Entity) Faction {
+	if c := core.Get[*Comp](entity); c != nil {
+		return c.Faction
+	}
+
+	return None
+}
+
+func Is(entity core.Entity, faction Faction) bool { return Of(entity) == faction }
+
+// Hostile reports whether entity goes after other.
+func Hostile(entity, other core.Entity) bool { return Relations.Get(Of(entity), Of(other)) == Enemies }
+
+// CanHit reports whether the attacks of entity hurt other.
+func CanHit(entity, other core.Entity) bool { return Relations.Get(Of(entity), Of(other)) != Allies }
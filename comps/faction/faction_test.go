@@ -0,0 +1,54 @@
+package faction_test
+
+import (
+	"encoding/json"
+	"game/comps/faction"
+	"testing"
+)
+
+func TestFactions(t *testing.T) {
+	relations := faction.NewMatrix()
+	relations.Set("covenant", faction.Monsters, faction.Enemies)
+	tests := []struct {
+		a, b faction.Faction
+		want faction.Relation
+	}{
+		{faction.Player, faction.Monsters, faction.Enemies},
+		{faction.Monsters, faction.Player, faction.Enemies},
+		{faction.Monsters, faction.Monsters, faction.Allies},
+		{"covenant", faction.Monsters, faction.Enemies},
+		{"covenant", faction.Player, faction.Neutral},
+		{faction.None, faction.None, faction.Neutral},
+	}
+	for _, test := range tests {
+		if got := relations.Get(test.a, test.b); got != test.want {
+			t.Errorf("%q and %q relation = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestMatrixJSON(t *testing.T) {
+	relations := faction.NewMatrix()
+	data := `[
+		{"factions": ["covenant", "monsters"], "relation": "enemies"},
+		{"factions": ["player", "monsters"], "relation": "neutral"}
+	]`
+	if err := json.Unmarshal([]byte(data), relations); err != nil {
+		t.Fatal(err)
+	}
+	if got := relations.Get("covenant", faction.Monsters); got != faction.Enemies {
+		t.Errorf("covenant and monsters relation = %d, want %d", got, faction.Enemies)
+	}
+	if got := relations.Get(faction.Monsters, faction.Player); got != faction.Neutral {
+		t.Errorf("monsters and player relation = %d, want it set back to %d", got, faction.Neutral)
+	}
+
+	for _, data := range []string{
+		`[{"factions": ["player", "monsters"], "relation": "friends"}]`,
+		`[{"factions": ["player"], "relation": "allies"}]`,
+	} {
+		if err := json.Unmarshal([]byte(data), faction.NewMatrix()); err == nil {
+			t.Errorf("%s loaded without an error", data)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package hitbox
 
 import (
+	"game/comps/faction"
+	"game/comps/stats"
 	"game/core"
 	"game/libs/bump"
 	"game/vars"
@@ -32,6 +34,7 @@ type Hitbox struct {
 
 type Comp struct {
 	HitFunc         HitFunc
+	Effects         []*stats.Effect
 	entity          core.Entity
 	space           *bump.Space
 	hurtBoxes       []*Hitbox
@@ -88,6 +91,8 @@ func (c *Comp) Draw(pipeline *core.Pipeline, entityPos ebiten.GeoM) {
 	})
 }
 
+func (c *Comp) Entity() core.Entity { return c.entity }
+
 func (c *Comp) PushHitbox(rect bump.Rect, block ContactType, updateContactType func() ContactType) {
 	box := &Hitbox{rect, c, block, updateContactType}
 	c.space.Set(box, rect, "hitbox")
@@ -121,9 +126,10 @@ func (c *Comp) HitFromHitBox(rect bump.Rect, damage float64, filterOut []*Comp)
 	var contacted []*Comp
 	contact := Hit
 	doesHit := map[*Comp]contactInfo{}
+	var hits []*Comp
 	for _, col := range cols {
 		if other, ok := col.Other.(*Hitbox); ok { //nolint: nestif
-			if slices.Contains(filterOut, other.comp) {
+			if slices.Contains(filterOut, other.comp) || !faction.CanHit(c.entity, other.comp.entity) {
 				continue
 			}
 			contacted = append(contacted, other.comp)
@@ -132,6 +138,7 @@ func (c *Comp) HitFromHitBox(rect bump.Rect, damage float64, filterOut []*Comp)
 			}
 			if doesHit[other.comp].col == nil {
 				doesHit[other.comp] = contactInfo{Hit, col}
+				hits = append(hits, other.comp)
 			}
 			if other.contactType > doesHit[other.comp].contactType {
 				doesHit[other.comp] = contactInfo{other.contactType, col}
@@ -141,10 +148,18 @@ func (c *Comp) HitFromHitBox(rect bump.Rect, damage float64, filterOut []*Comp)
 		}
 	}
 
-	for comp, info := range doesHit {
+	// Fixed for replays: the player and projectiles have no ID, so they're hit first, in contact order.
+	slices.SortStableFunc(hits, func(a, b *Comp) int { return vars.World.CompareIDs(a.entity, b.entity) })
+	for _, comp := range hits {
+		info := doesHit[comp]
 		if comp.HitFunc != nil {
 			comp.HitFunc(c.entity, info.col, damage, info.contactType)
 		}
+		if otherStats := core.Get[*stats.Comp](comp.entity); info.contactType == Hit && otherStats != nil {
+			for _, effect := range c.Effects {
+				otherStats.ApplyEffect(effect)
+			}
+		}
 	}
 
 	return contact, append(filterOut, contacted...)
@@ -0,0 +1,39 @@
+package hitbox_test
+
+import (
+	"game/comps/hitbox"
+	"game/core"
+	"game/core/coretest"
+	"game/libs/bump"
+	"game/vars"
+	"slices"
+	"testing"
+)
+
+func TestHitOrder(t *testing.T) {
+	coretest.NewWorld()
+	attacker := coretest.NewEntity(0, 0, 8, 8)
+	attackerHitbox := &hitbox.Comp{}
+	attacker.Add(attackerHitbox)
+	vars.World.Add(attacker)
+	var hitIDs []uint
+	targets := map[uint]*hitbox.Comp{}
+	for i := range 16 {
+		id := uint(100 - i)
+		target := coretest.NewEntity(0, 0, 8, 8)
+		targets[id] = &hitbox.Comp{HitFunc: func(_ core.Entity, _ *bump.Collision, _ float64, _ hitbox.ContactType) {
+			hitIDs = append(hitIDs, id)
+		}}
+		target.Add(targets[id])
+		vars.World.AddWithID(target, id)
+	}
+	vars.World.Update(0)
+	for _, targetHitbox := range targets {
+		targetHitbox.PushHitbox(bump.Rect{W: 8, H: 8}, hitbox.Hit, nil)
+	}
+
+	attackerHitbox.HitFromHitBox(bump.Rect{W: 8, H: 8}, 1, nil)
+	if len(hitIDs) != 16 || !slices.IsSorted(hitIDs) {
+		t.Errorf("targets hit in the order %v, want all 16 in ID order", hitIDs)
+	}
+}
@@ -0,0 +1,99 @@
+package inventory
+
+import (
+	"game/core"
+	"image/color"
+	"maps"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type Kind int
+
+const (
+	Consumable Kind = iota
+	Key
+	Charm
+)
+
+var kindNames = map[Kind]string{Consumable: "Consumable", Key: "Key", Charm: "Charm"}
+
+func (k Kind) String() string { return kindNames[k] }
+
+type Item struct {
+	ID, Name, Description string
+	Kind                  Kind
+	Color                 color.Color
+	// Use is called when a Consumable is used, and once for a Charm when its owner is built or picks it up.
+	Use func(owner core.Entity)
+}
+
+var items = map[string]*Item{}
+
+func Register(item *Item)    { items[item.ID] = item }
+func Lookup(id string) *Item { return items[id] }
+
+type Comp struct {
+	Items  map[string]int
+	entity core.Entity
+}
+
+func (c *Comp) Init(entity core.Entity) {
+	c.entity = entity
+	if c.Items == nil {
+		c.Items = map[string]int{}
+	}
+	for _, item := range c.Owned() {
+		if item.Kind == Charm && item.Use != nil {
+			item.Use(entity)
+		}
+	}
+}
+
+func (c *Comp) Update(_ float64)                     {}
+func (c *Comp) Remove()                              {}
+func (c *Comp) Draw(_ *core.Pipeline, _ ebiten.GeoM) {}
+
+func (c *Comp) Count(id string) int { return c.Items[id] }
+func (c *Comp) Has(id string) bool  { return c.Items[id] > 0 }
+
+func (c *Comp) Add(id string, count int) {
+	item := Lookup(id)
+	if item == nil || count <= 0 {
+		return
+	}
+	if item.Kind == Charm && !c.Has(id) && item.Use != nil {
+		item.Use(c.entity)
+	}
+	c.Items[id] += count
+}
+
+// Use spends one Consumable, reporting whether there was one to use.
+func (c *Comp) Use(id string) bool {
+	item := Lookup(id)
+	if item == nil || item.Kind != Consumable || !c.Has(id) {
+		return false
+	}
+	if c.Items[id]--; c.Items[id] == 0 {
+		delete(c.Items, id)
+	}
+	if item.Use != nil {
+		item.Use(c.entity)
+	}
+
+	return true
+}
+
+// Owned lists the registered items held, sorted by kind and then by ID.
+func (c *Comp) Owned() []*Item {
+	var owned []*Item
+	for _, id := range slices.Sorted(maps.Keys(c.Items)) {
+		if item := Lookup(id); item != nil && c.Items[id] > 0 {
+			owned = append(owned, item)
+		}
+	}
+	slices.SortStableFunc(owned, func(a, b *Item) int { return int(a.Kind - b.Kind) })
+
+	return owned
+}
@@ -21,11 +21,11 @@ type Comp struct {
 	ColorScale   color.Color
 	RollingTime  time.Duration
 	Normal       bool
-	rollingTimer *time.Timer
+	rollingTimer *core.Task
 	w, h         float64
 }
 
-func (c *Comp) Init(_ core.Entity) {
+func (c *Comp) Init(entity core.Entity) {
 	is := c.Image.Bounds().Size()
 	w, h := is.X, is.Y
 	c.w, c.h = float64(w), float64(h)
@@ -33,20 +33,11 @@ func (c *Comp) Init(_ core.Entity) {
 		c.ColorScale = color.White
 	}
 	if c.RollingTime != 0 {
-		c.rollingTimer = time.AfterFunc(c.RollingTime, func() {
-			c.R += math.Pi / 2
-			if c.rollingTimer != nil {
-				c.rollingTimer.Reset(c.RollingTime)
-			}
-		})
+		c.rollingTimer = vars.World.Scheduler.Every(entity, c.RollingTime.Seconds(), func() { c.R += math.Pi / 2 })
 	}
 }
 
-func (c *Comp) Remove() {
-	if c.rollingTimer != nil {
-		c.rollingTimer.Stop()
-	}
-}
+func (c *Comp) Remove() { c.rollingTimer.Cancel() }
 
 func (c *Comp) Update(_ float64) {}
 
@@ -0,0 +1,168 @@
+package stats
+
+import (
+	"game/assets"
+	"game/core"
+	"game/utils"
+	"game/vars"
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const effectIconSize = 7
+
+// Effect is a status applied over time, every new application adds a stack and restarts its duration.
+// Modifiers apply once no matter the stacks, OnTick gets the stack count to scale with.
+type Effect struct {
+	Name, Icon         string
+	Color              color.Color
+	Duration           float64
+	MaxStacks          int
+	TickSeconds        float64
+	OnTick             func(c *Comp, stacks int)
+	SpeedMult          float64
+	DamageMult         float64
+	StaminaRecoverMult float64
+	HideHud            bool
+}
+
+var (
+	Bleed = &Effect{
+		Name: "Bleed", Icon: "B", Color: color.RGBA{172, 50, 50, 255},
+		Duration: 4, MaxStacks: 3, TickSeconds: 0.5,
+		OnTick: func(c *Comp, stacks int) { c.AddHealth(-float64(stacks)) },
+	}
+	Poison = &Effect{
+		Name: "Poison", Icon: "P", Color: color.RGBA{106, 190, 48, 255},
+		Duration: 10, MaxStacks: 5, TickSeconds: 1,
+		OnTick:             func(c *Comp, stacks int) { c.AddHealth(-0.5 * float64(stacks)) },
+		StaminaRecoverMult: 0.5,
+	}
+	Cripple = &Effect{
+		Name: "Cripple", Icon: "C", Color: color.RGBA{143, 86, 59, 255},
+		Duration: 3, MaxStacks: 1,
+		SpeedMult: 0.6, DamageMult: 0.8,
+	}
+	Dread = &Effect{
+		Name: "Dread", Icon: "?", Color: color.RGBA{118, 66, 138, 255},
+		Duration: 8, MaxStacks: 1,
+		HideHud: true,
+	}
+
+	effectIcons = map[*Effect]*ebiten.Image{}
+)
+
+type activeEffect struct {
+	effect               *Effect
+	stacks               int
+	remaining, tickTimer float64
+}
+
+func (c *Comp) ApplyEffect(effect *Effect) {
+	if c.Health <= 0 {
+		return
+	}
+	for _, active := range c.effects {
+		if active.effect == effect {
+			active.stacks = min(active.stacks+1, max(effect.MaxStacks, 1))
+			active.remaining = effect.Duration
+
+			return
+		}
+	}
+	c.effects = append(c.effects, &activeEffect{effect: effect, stacks: 1, remaining: effect.Duration, tickTimer: effect.TickSeconds})
+}
+
+func (c *Comp) RemoveEffect(effect *Effect) {
+	c.effects = slices.DeleteFunc(c.effects, func(active *activeEffect) bool { return active.effect == effect })
+}
+
+// EffectStacks returns how many stacks of effect are active, 0 when it is not.
+func (c *Comp) EffectStacks(effect *Effect) int {
+	for _, active := range c.effects {
+		if active.effect == effect {
+			return active.stacks
+		}
+	}
+
+	return 0
+}
+
+func (c *Comp) SpeedMult() float64 {
+	return c.effectsMult(func(e *Effect) float64 { return e.SpeedMult })
+}
+
+func (c *Comp) DamageMult() float64 {
+	return c.effectsMult(func(e *Effect) float64 { return e.DamageMult })
+}
+
+func (c *Comp) StaminaRecoverMult() float64 {
+	return c.effectsMult(func(e *Effect) float64 { return e.StaminaRecoverMult })
+}
+
+func (c *Comp) effectsMult(modifier func(e *Effect) float64) float64 {
+	mult := 1.0
+	for _, active := range c.effects {
+		if m := modifier(active.effect); m != 0 {
+			mult *= m
+		}
+	}
+
+	return mult
+}
+
+func (c *Comp) hudHidden() bool {
+	return slices.ContainsFunc(c.effects, func(active *activeEffect) bool { return active.effect.HideHud })
+}
+
+func (c *Comp) updateEffects(dt float64) {
+	for _, active := range c.effects {
+		active.remaining -= dt
+		if active.effect.OnTick == nil || active.effect.TickSeconds <= 0 {
+			continue
+		}
+		for active.tickTimer -= dt; active.tickTimer <= 0; active.tickTimer += active.effect.TickSeconds {
+			active.effect.OnTick(c, active.stacks)
+		}
+	}
+	c.effects = slices.DeleteFunc(c.effects, func(active *activeEffect) bool { return active.remaining <= 0 || c.Health <= 0 })
+}
+
+func (c *Comp) drawEffects(pipeline *core.Pipeline, geoM ebiten.GeoM, y float64) {
+	for i, active := range c.effects {
+		op := &ebiten.DrawImageOptions{GeoM: geoM}
+		op.GeoM.Translate(float64(i*(effectIconSize+1)), y)
+		icon := effectIcon(active.effect)
+		// Blink when about to run out.
+		if active.remaining < 1 && math.Mod(active.remaining, 0.25) < 0.125 {
+			op.ColorScale.ScaleAlpha(0.5)
+		}
+		pipeline.Add(vars.PipelineScreenTag, vars.PipelineUILayer, func(screen *ebiten.Image) { screen.DrawImage(icon, op) })
+		pipeline.Add(vars.PipelineNormalMapTag, vars.PipelineUILayer, func(normalMap *ebiten.Image) {
+			normalMap.DrawImage(icon, &ebiten.DrawImageOptions{GeoM: op.GeoM, Blend: ebiten.BlendDestinationOut})
+		})
+	}
+}
+
+func effectIcon(effect *Effect) *ebiten.Image {
+	if icon, ok := effectIcons[effect]; ok {
+		return icon
+	}
+	icon := ebiten.NewImage(effectIconSize, effectIconSize)
+	icon.Fill(borderColor)
+	inner := ebiten.NewImage(effectIconSize-2, effectIconSize-2)
+	inner.Fill(effect.Color)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(1, 1)
+	icon.DrawImage(inner, op)
+	w, _ := utils.TextSize(effect.Icon, assets.NanoFont)
+	op.GeoM.Reset()
+	op.GeoM.Translate(math.Floor((effectIconSize-w)/2), 0)
+	utils.DrawText(icon, effect.Icon, assets.NanoFont, op)
+	effectIcons[effect] = icon
+
+	return icon
+}
@@ -10,7 +10,6 @@ import (
 	"image/color"
 	"math"
 	"strconv"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -58,7 +57,7 @@ func init() {
 }
 
 type Comp struct {
-	Hud, Pause, NoDebug                                    bool
+	Hud, Pause, NoDebug, NoHeadBar                         bool
 	MaxHealth, Health                                      float64
 	MaxStamina, Stamina                                    float64
 	MaxPoise, Poise                                        float64
@@ -69,9 +68,10 @@ type Comp struct {
 	StaminaRecoverRate, PoiseRecoverSeconds                float64
 	healthTween, staminaTween, poiseTween, attackMultTween *gween.Tween
 	healthLag, staminaLag, poiseLag                        float64
-	poiseTimer                                             *time.Timer
+	poiseTimer                                             *core.Task
 	entity                                                 core.Entity
 	headHealthTimer                                        float64
+	effects                                                []*activeEffect
 }
 
 func (c *Comp) Init(entity core.Entity) {
@@ -117,9 +117,31 @@ func (c *Comp) Init(entity core.Entity) {
 	c.entity = entity
 }
 
-func (c *Comp) Remove() {
-	if c.poiseTimer != nil {
-		c.poiseTimer.Stop()
+func (c *Comp) Remove() { c.poiseTimer.Cancel() }
+
+// Reset refills the stats and clears the effects, leaving a dying entity dying.
+func (c *Comp) Reset() {
+	if c.Health <= 0 {
+		return
+	}
+	c.poiseTimer.Cancel()
+	c.Health, c.Stamina, c.Poise = c.MaxHealth, c.MaxStamina, c.MaxPoise
+	c.healthLag, c.staminaLag, c.poiseLag = c.Health, c.Stamina, c.Poise
+	c.healthTween, c.staminaTween, c.poiseTween = nil, nil, nil
+	c.effects = nil
+	c.headHealthTimer = 0
+}
+
+// Carry takes over the health, stamina, poise, heals and effects of other, the comp of the entity it replaces, within
+// its own maxima.
+func (c *Comp) Carry(other *Comp) {
+	c.Health, c.Stamina = min(other.Health, c.MaxHealth), min(other.Stamina, c.MaxStamina)
+	c.Poise, c.Heal = min(other.Poise, c.MaxPoise), min(other.Heal, c.MaxHeal)
+	c.healthLag, c.staminaLag, c.poiseLag = c.Health, c.Stamina, c.Poise
+	c.effects = nil
+	for _, active := range other.effects {
+		carried := *active
+		c.effects = append(c.effects, &carried)
 	}
 }
 
@@ -135,8 +157,9 @@ func (c *Comp) Update(dt float64) {
 		}
 	}
 
+	c.updateEffects(dt)
 	if !c.Pause {
-		c.Stamina += c.StaminaRecoverRate * dt
+		c.Stamina += c.StaminaRecoverRate * c.StaminaRecoverMult() * dt
 		c.Stamina = math.Min(c.Stamina, c.MaxStamina)
 		c.Poise = math.Min(c.Poise, c.MaxPoise)
 	} else if c.Hud {
@@ -175,7 +198,7 @@ func (c *Comp) Draw(pipeline *core.Pipeline, entityPos ebiten.GeoM) {
 
 		return
 	}
-	if c.Health >= c.MaxHealth || c.Health <= 0 {
+	if c.NoHeadBar || c.Health >= c.MaxHealth || c.Health <= 0 {
 		return
 	}
 	if c.headHealthTimer <= 0 {
@@ -184,6 +207,7 @@ func (c *Comp) Draw(pipeline *core.Pipeline, entityPos ebiten.GeoM) {
 	c.drawHeadHealthBar(pipeline, entityPos, c.Health, c.MaxHealth, c.healthLag)
 }
 
+func (c *Comp) HealthLag() float64      { return c.healthLag }
 func (c *Comp) HealthPercent() float64  { return c.Health / c.MaxHealth }
 func (c *Comp) StaminaPercent() float64 { return c.Stamina / c.MaxStamina }
 func (c *Comp) PoisePercent() float64   { return c.Poise / c.MaxPoise }
@@ -213,14 +237,12 @@ func (c *Comp) AddPoise(amount float64) {
 	c.poiseTween = gween.New(float32(c.poiseLag), float32(c.Poise), 1, ease.Linear)
 
 	if amount < 0 {
-		if c.poiseTimer != nil {
-			c.poiseTimer.Stop()
-		}
+		c.poiseTimer.Cancel()
 		timer := c.PoiseRecoverSeconds
 		if c.Poise <= 0 {
 			timer = c.PoiseRecoverSeconds / 10
 		}
-		c.poiseTimer = time.AfterFunc(time.Duration(float64(time.Second)*timer), func() {
+		c.poiseTimer = vars.World.Scheduler.After(c.entity, timer, func() {
 			c.Poise = c.MaxPoise
 			c.poiseLag = c.Poise
 		})
@@ -248,13 +270,16 @@ func (c *Comp) drawHud(pipeline *core.Pipeline) {
 	})
 
 	const staminaVisualScale = 0.8
-	c.drawSegment(pipeline, op.GeoM, 0, c.Health, c.MaxHealth, c.healthLag, healthColor)
-	c.drawSegment(
-		pipeline, op.GeoM, 1, c.Stamina*staminaVisualScale, c.MaxStamina*staminaVisualScale, c.staminaLag*staminaVisualScale, staminaColor,
-	)
-	c.drawAttackMult(pipeline, op.GeoM)
+	if !c.hudHidden() {
+		c.drawSegment(pipeline, op.GeoM, 0, c.Health, c.MaxHealth, c.healthLag, healthColor)
+		c.drawSegment(
+			pipeline, op.GeoM, 1, c.Stamina*staminaVisualScale, c.MaxStamina*staminaVisualScale, c.staminaLag*staminaVisualScale, staminaColor,
+		)
+		c.drawAttackMult(pipeline, op.GeoM)
+	}
 	c.drawCount(pipeline, op.GeoM, 2, c.Heal, 0)
 	c.drawCount(pipeline, op.GeoM, 3, c.Exp, 2)
+	c.drawEffects(pipeline, op.GeoM, vars.BarMiddleH*5+4)
 }
 
 func (c *Comp) drawSegment(pipeline *core.Pipeline, geoM ebiten.GeoM, y, current, max, lag float64, barColor color.Color) {
@@ -0,0 +1,93 @@
+package stats_test
+
+import (
+	"game/comps/stats"
+	"testing"
+)
+
+func newStats() *stats.Comp {
+	c := &stats.Comp{MaxHealth: 100}
+	c.Init(nil)
+
+	return c
+}
+
+// update runs the comp for the given seconds in quarter second steps.
+func update(c *stats.Comp, seconds float64) {
+	for range int(seconds * 4) {
+		c.Update(0.25)
+	}
+}
+
+func TestEffects(t *testing.T) {
+	tests := []struct {
+		name         string
+		effect       *stats.Effect
+		applications int
+		seconds      float64
+		wantStacks   int
+		wantHealth   float64
+	}{
+		{name: "bleed ticks per stack", effect: stats.Bleed, applications: 2, seconds: 1, wantStacks: 2, wantHealth: 96},
+		{name: "bleed stacks up to its max", effect: stats.Bleed, applications: 5, seconds: 0.5, wantStacks: 3, wantHealth: 97},
+		{name: "poison ticks every second", effect: stats.Poison, applications: 1, seconds: 2.5, wantStacks: 1, wantHealth: 99},
+		{name: "bleed expires", effect: stats.Bleed, applications: 1, seconds: 5, wantStacks: 0, wantHealth: 92},
+		{name: "cripple expires without ticking", effect: stats.Cripple, applications: 2, seconds: 3, wantStacks: 0, wantHealth: 100},
+		{name: "dread lasts", effect: stats.Dread, applications: 1, seconds: 4, wantStacks: 1, wantHealth: 100},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newStats()
+			for range test.applications {
+				c.ApplyEffect(test.effect)
+			}
+			update(c, test.seconds)
+			if stacks := c.EffectStacks(test.effect); stacks != test.wantStacks {
+				t.Errorf("%d stacks after %vs, want %d", stacks, test.seconds, test.wantStacks)
+			}
+			if c.Health != test.wantHealth {
+				t.Errorf("health %v after %vs, want %v", c.Health, test.seconds, test.wantHealth)
+			}
+		})
+	}
+}
+
+func TestEffectRestarts(t *testing.T) {
+	c := newStats()
+	c.ApplyEffect(stats.Cripple)
+	update(c, 2)
+	c.ApplyEffect(stats.Cripple)
+	update(c, 2)
+	if c.EffectStacks(stats.Cripple) != 1 {
+		t.Error("cripple ran out 4s after being first applied, want its duration restarted by the second")
+	}
+}
+
+func TestEffectModifiers(t *testing.T) {
+	c := newStats()
+	c.ApplyEffect(stats.Poison)
+	c.ApplyEffect(stats.Poison)
+	c.ApplyEffect(stats.Cripple)
+	if mult := c.StaminaRecoverMult(); mult != 0.5 {
+		t.Errorf("stamina recover mult %v with two poison stacks, want 0.5 applied once", mult)
+	}
+	if speed, damage := c.SpeedMult(), c.DamageMult(); speed != 0.6 || damage != 0.8 {
+		t.Errorf("speed and damage mults %v, %v while crippled, want 0.6, 0.8", speed, damage)
+	}
+
+	c.RemoveEffect(stats.Poison)
+	if c.EffectStacks(stats.Poison) != 0 || c.StaminaRecoverMult() != 1 {
+		t.Error("poison still active once removed")
+	}
+}
+
+func TestEffectsOnDeath(t *testing.T) {
+	c := newStats()
+	c.ApplyEffect(stats.Bleed)
+	c.Health = 0
+	c.Update(0.25)
+	c.ApplyEffect(stats.Poison)
+	if c.EffectStacks(stats.Bleed) != 0 || c.EffectStacks(stats.Poison) != 0 {
+		t.Error("effects kept or applied on a dead comp")
+	}
+}
@@ -2,6 +2,7 @@ package textbox
 
 import (
 	"game/assets"
+	"game/comps/faction"
 	"game/core"
 	"game/ext"
 	"game/libs/bump"
@@ -96,7 +97,7 @@ func (c *Comp) Remove() {}
 func (c *Comp) Update(dt float64) {
 	active := false
 	for _, e := range ext.QueryItems(c.entity, c.Area(), "body") {
-		if core.GetFlag(e, vars.PlayerTeamFlag) {
+		if faction.Is(e, faction.Player) {
 			active = true
 
 			break
@@ -107,7 +108,7 @@ func (c *Comp) Update(dt float64) {
 			c.advanceFlickerTimer = 0
 			c.advanceFlicker = !c.advanceFlicker
 		}
-		if vars.Pad.KeyPressed(utils.KeyDown) && c.advanceState < c.advanceMax {
+		if vars.Controls.KeyPressed(utils.KeyDown) && c.advanceState < c.advanceMax {
 			c.advanceState++
 		}
 	}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"fmt"
+	"game/libs/bump"
+	"slices"
+)
+
+// Activation decides when the World updates an entity, set in Tiled with the activation property.
+type Activation int
+
+const (
+	SleepOffScreen Activation = iota // The default, updated while in frame.
+	AlwaysActive
+	RoomBound    // Updated while in the room the camera is in, wherever it is in it.
+	ResetOnLeave // Room bound, and put back at its spawn once the camera leaves its spawn room.
+)
+
+var activationNames = map[string]Activation{
+	"screen": SleepOffScreen,
+	"always": AlwaysActive,
+	"room":   RoomBound,
+	"reset":  ResetOnLeave,
+}
+
+// Resetter is an entity or component that can go back to how it spawned, for the ResetOnLeave activation.
+type Resetter interface {
+	Reset()
+}
+
+type activation struct {
+	policy         Activation
+	spawnX, spawnY float64
+	spawnRoom      *bump.Rect
+}
+
+func ParseActivation(name string) (Activation, error) {
+	if activation, ok := activationNames[name]; ok {
+		return activation, nil
+	}
+
+	return SleepOffScreen, fmt.Errorf("unknown activation %q", name)
+}
+
+// SetActivation gives entity its policy, its current position and room being where it resets to.
+func (w *World) SetActivation(entity Entity, policy Activation) {
+	if policy == SleepOffScreen {
+		delete(w.activations, entity)
+
+		return
+	}
+	x, y := entity.Position()
+	a := &activation{policy: policy, spawnX: x, spawnY: y}
+	rect := bump.NewRect(entity.Rect())
+	rooms := w.Camera.Rooms()
+	for i := range rooms {
+		if bump.Overlaps(rooms[i], rect) {
+			a.spawnRoom = &rooms[i]
+
+			break
+		}
+	}
+	w.activations[entity] = a
+}
+
+func (w *World) active(entity Entity) bool {
+	a := w.activations[entity]
+	if a == nil {
+		return w.Camera.InFrame(entity, 1, 1)
+	}
+	switch a.policy {
+	case AlwaysActive:
+		return true
+	case RoomBound, ResetOnLeave:
+		return w.room != nil && bump.Overlaps(*w.room, bump.NewRect(entity.Rect()))
+	default:
+		return w.Camera.InFrame(entity, 1, 1)
+	}
+}
+
+// resetLeftRooms puts back at their spawn the ResetOnLeave entities of the room the camera left.
+func (w *World) resetLeftRooms(left *bump.Rect) {
+	if left == nil {
+		return
+	}
+	// Sorted so replays reset in the same order, the entities without an ID first as they were added.
+	var reset []Entity
+	for _, entity := range w.entities {
+		if a := w.activations[entity]; a != nil && a.policy == ResetOnLeave && a.spawnRoom == left {
+			reset = append(reset, entity)
+		}
+	}
+	slices.SortStableFunc(reset, w.CompareIDs)
+	for _, entity := range reset {
+		a := w.activations[entity]
+		entity.SetPosition(a.spawnX, a.spawnY)
+		if resetter, ok := entity.(Resetter); ok {
+			resetter.Reset()
+		}
+		for _, c := range entity.Components() {
+			if resetter, ok := c.(Resetter); ok {
+				resetter.Reset()
+			}
+		}
+	}
+}
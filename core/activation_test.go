@@ -0,0 +1,117 @@
+package core_test
+
+import (
+	"game/comps/anim"
+	"game/comps/stats"
+	"game/core"
+	"game/libs/bump"
+	"game/vars"
+	"slices"
+	"testing"
+)
+
+type countingEntity struct {
+	*core.BaseEntity
+	updates int
+}
+
+func (e *countingEntity) Init()            {}
+func (e *countingEntity) Update(_ float64) { e.updates++ }
+
+func TestActivation(t *testing.T) {
+	world := core.NewWorld(320, 180)
+	world.Map = &core.Map{}
+	far := func() *countingEntity {
+		return &countingEntity{BaseEntity: &core.BaseEntity{X: 5000, Y: 5000, W: 16, H: 16}}
+	}
+	sleeping, awake := far(), far()
+	world.Add(sleeping)
+	world.Add(awake)
+	always, err := core.ParseActivation("always")
+	if err != nil {
+		t.Fatal(err)
+	}
+	world.SetActivation(awake, always)
+	world.Update(1)
+
+	if sleeping.updates != 0 || awake.updates != 1 {
+		t.Errorf("updates off-screen = %d sleeping, %d always active, want 0 and 1", sleeping.updates, awake.updates)
+	}
+	if _, err := core.ParseActivation("never"); err == nil {
+		t.Error("unknown activation parsed")
+	}
+}
+
+func TestResetOnLeave(t *testing.T) {
+	world := core.NewWorld(320, 180)
+	world.Map = &core.Map{}
+	vars.World = world
+	world.Camera.SetRooms([]bump.Rect{{X: 0, Y: 0, W: 320, H: 180}, {X: 320, Y: 0, W: 320, H: 180}})
+	viewer := &countingEntity{BaseEntity: &core.BaseEntity{X: 150, Y: 80, W: 16, H: 16}}
+	guard := &countingEntity{BaseEntity: &core.BaseEntity{X: 50, Y: 80, W: 16, H: 16}}
+	guardStats := &stats.Comp{MaxHealth: 10}
+	guardAnim := &anim.Comp{FilesName: "skeleman"}
+	guard.Add(guardStats, guardAnim)
+	wanderer := &countingEntity{BaseEntity: &core.BaseEntity{X: 250, Y: 80, W: 16, H: 16}}
+	for _, e := range []*countingEntity{viewer, guard, wanderer} {
+		world.Add(e)
+	}
+	world.Camera.Follow(viewer)
+	world.SetActivation(guard, core.ResetOnLeave)
+	world.SetActivation(wanderer, core.RoomBound)
+	world.Update(0)
+
+	guard.SetPosition(100, 60)
+	guardStats.Health = 3
+	guardAnim.SetState("AttackShort")
+	wanderer.SetPosition(200, 60)
+	viewer.SetPosition(400, 80)
+	updates := wanderer.updates
+	world.Update(1.0 / 60)
+
+	if x, y := guard.Position(); x != 50 || y != 80 || guardStats.Health != 10 {
+		t.Errorf("guard at %v,%v with %v health after leaving its room, want back at 50,80 with 10", x, y,
+			guardStats.Health)
+	}
+	if guardAnim.State != vars.IdleTag {
+		t.Errorf("guard anim state = %s after leaving its room, want %s", guardAnim.State, vars.IdleTag)
+	}
+	if x, y := wanderer.Position(); x != 200 || y != 60 || wanderer.updates != updates {
+		t.Errorf("room bound entity at %v,%v updated %d times after leaving its room, want left at 200,60 and not updated",
+			x, y, wanderer.updates-updates)
+	}
+}
+
+// resetRecorder appends its ID to resets when reset.
+type resetRecorder struct {
+	countingEntity
+	resets *[]uint
+}
+
+func (e *resetRecorder) Reset() { *e.resets = append(*e.resets, vars.World.GetID(e)) }
+
+func TestResetOnLeaveOrder(t *testing.T) {
+	world := core.NewWorld(320, 180)
+	world.Map = &core.Map{}
+	vars.World = world
+	world.Camera.SetRooms([]bump.Rect{{X: 0, Y: 0, W: 320, H: 180}, {X: 320, Y: 0, W: 320, H: 180}})
+	viewer := &countingEntity{BaseEntity: &core.BaseEntity{X: 150, Y: 80, W: 16, H: 16}}
+	world.Add(viewer)
+	world.Camera.Follow(viewer)
+	resets := []uint{}
+	for i := range 16 {
+		guard := &resetRecorder{
+			countingEntity: countingEntity{BaseEntity: &core.BaseEntity{X: float64(10 * i), Y: 80, W: 16, H: 16}},
+			resets:         &resets,
+		}
+		world.AddWithID(guard, uint(100-i))
+		world.SetActivation(guard, core.ResetOnLeave)
+	}
+	world.Update(0)
+
+	viewer.SetPosition(400, 80)
+	world.Update(1.0 / 60)
+	if len(resets) != 16 || !slices.IsSorted(resets) {
+		t.Errorf("guards reset in the order %v, want all 16 in ID order", resets)
+	}
+}
@@ -3,42 +3,51 @@ package core
 import "reflect"
 
 type BaseEntity struct {
-	X, Y, H, W     float64
-	components     []Component
-	tagToComponent map[string]int
+	X, Y, H, W float64
+	components []Component
+	ids        []ComponentID // The ID each component was added under, in the order of components.
+	slots      []Component   // Indexed by ComponentID.
 }
 
 func (e *BaseEntity) Components() []Component                    { return e.components }
+func (e *BaseEntity) ComponentIDs() []ComponentID                { return e.ids }
 func (e *BaseEntity) Position() (float64, float64)               { return e.X, e.Y }
 func (e *BaseEntity) SetPosition(x, y float64)                   { e.X = x; e.Y = y }
 func (e *BaseEntity) Rect() (float64, float64, float64, float64) { return e.X, e.Y, e.W, e.H }
 func (e *BaseEntity) SetSize(w, h float64)                       { e.W = w; e.H = h }
 
 func (e *BaseEntity) Add(adding ...Component) {
-	if e.tagToComponent == nil {
-		e.tagToComponent = map[string]int{}
-	}
 	for _, c := range adding {
-		tag := reflect.TypeOf(c).String()
-		e.tagToComponent[tag] = len(e.components)
+		id := typeID(reflect.TypeOf(c))
+		e.setSlot(id, c)
 		e.components = append(e.components, c)
+		e.ids = append(e.ids, id)
 	}
 }
 
 func (e *BaseEntity) AddWithTag(component Component, tag string) (Component, bool) {
-	if e.components[e.tagToComponent[tag]] != nil {
-		return e.components[e.tagToComponent[tag]], false
+	id := TagID(tag)
+	if c := e.Component(id); c != nil {
+		return c, false
 	}
-	e.tagToComponent[tag] = len(e.components)
+	e.setSlot(id, component)
 	e.components = append(e.components, component)
+	e.ids = append(e.ids, id)
 
 	return component, true
 }
 
-func (e *BaseEntity) Component(tag string) Component {
-	if idx, ok := e.tagToComponent[tag]; ok {
-		return e.components[idx]
+func (e *BaseEntity) Component(id ComponentID) Component {
+	if int(id) < len(e.slots) {
+		return e.slots[id]
 	}
 
 	return nil
 }
+
+func (e *BaseEntity) setSlot(id ComponentID, c Component) {
+	if int(id) >= len(e.slots) {
+		e.slots = append(e.slots, make([]Component, int(id)+1-len(e.slots))...)
+	}
+	e.slots[id] = c
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"game/libs/bump"
+	"reflect"
+	"slices"
+)
+
+// Bus hands every published event to the handlers subscribed to its type, right away and in the order they
+// subscribed.
+type Bus struct {
+	handlers map[reflect.Type][]*handler
+}
+
+type handler struct{ handle any }
+
+func NewBus() *Bus { return &Bus{handlers: map[reflect.Type][]*handler{}} }
+
+// Subscribe calls handle with each E published on bus until the returned unsubscribe is called.
+func Subscribe[E any](bus *Bus, handle func(event E)) (unsubscribe func()) {
+	eventType := reflect.TypeFor[E]()
+	h := &handler{handle}
+	// Never appended in place, so the handlers being published to aren't changed under it.
+	bus.handlers[eventType] = append(slices.Clip(bus.handlers[eventType]), h)
+
+	return func() {
+		bus.handlers[eventType] = slices.DeleteFunc(slices.Clone(bus.handlers[eventType]), func(other *handler) bool {
+			return other == h
+		})
+	}
+}
+
+func Publish[E any](bus *Bus, event E) {
+	for _, h := range bus.handlers[reflect.TypeFor[E]()] {
+		h.handle.(func(E))(event) //nolint: forcetypeassert
+	}
+}
+
+// EntityDamaged is published when an entity gets hit, before it reacts to it.
+type EntityDamaged struct {
+	Entity, Source Entity
+	Damage         float64
+}
+
+// EntityDied is published once a dying entity is removed, with the Exp it drops.
+type EntityDied struct {
+	Entity Entity
+	Exp    int
+}
+
+// HitLanded is published each time an attack reaches more entities, Mult being its combo multiplier.
+type HitLanded struct {
+	Attacker Entity
+	Hits     int
+	Mult     float64
+}
+
+type ItemPicked struct {
+	Entity Entity
+	Item   string
+	Count  int
+}
+
+// RoomEntered is published when the camera settles in another room.
+type RoomEntered struct {
+	Room bump.Rect
+}
+
+type RestAtGrave struct {
+	Grave Entity
+}
@@ -0,0 +1,26 @@
+package core_test
+
+import (
+	"game/core"
+	"slices"
+	"testing"
+)
+
+func TestBus(t *testing.T) {
+	bus := core.NewBus()
+	var got []int
+	var unsubscribe func()
+	unsubscribe = core.Subscribe(bus, func(event core.ItemPicked) {
+		got = append(got, event.Count)
+		unsubscribe()
+	})
+	core.Subscribe(bus, func(event core.ItemPicked) { got = append(got, event.Count*10) })
+	core.Subscribe(bus, func(core.EntityDied) { t.Error("EntityDied handler called for an ItemPicked") })
+
+	core.Publish(bus, core.ItemPicked{Count: 1})
+	core.Publish(bus, core.ItemPicked{Count: 2})
+
+	if want := []int{1, 10, 20}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
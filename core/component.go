@@ -0,0 +1,114 @@
+package core
+
+import (
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ComponentID indexes the components of an entity, given to each component type and tag the first time it's seen.
+type ComponentID int
+
+// componentRegistry is replaced instead of updated, so the lookups on every Get don't need a lock.
+type componentRegistry struct {
+	types map[reflect.Type]ComponentID
+	tags  map[string]ComponentID
+	names []string // Indexed by ComponentID.
+}
+
+var (
+	registry      atomic.Pointer[componentRegistry]
+	registryMutex sync.Mutex
+)
+
+func init() {
+	registry.Store(&componentRegistry{types: map[reflect.Type]ComponentID{}, tags: map[string]ComponentID{}})
+}
+
+// ComponentIDOf returns the ID of the component type T.
+func ComponentIDOf[T Component]() ComponentID { return typeID(reflect.TypeFor[T]()) }
+
+// TagID returns the ID of a component tag, the tag of a component added without one being its type name.
+func TagID(tag string) ComponentID {
+	if id, ok := registry.Load().tags[tag]; ok {
+		return id
+	}
+
+	return register(nil, tag)
+}
+
+// lookupTag returns the ID of a tag already seen, without registering it.
+func lookupTag(tag string) (ComponentID, bool) {
+	id, ok := registry.Load().tags[tag]
+
+	return id, ok
+}
+
+func typeID(t reflect.Type) ComponentID {
+	if id, ok := registry.Load().types[t]; ok {
+		return id
+	}
+
+	return register(t, t.String())
+}
+
+func register(t reflect.Type, tag string) ComponentID {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	current := registry.Load()
+	id, ok := current.tags[tag]
+	if !ok {
+		id = ComponentID(len(current.tags))
+	}
+	next := &componentRegistry{
+		types: maps.Clone(current.types), tags: maps.Clone(current.tags), names: current.names,
+	}
+	if !ok {
+		next.tags[tag] = id
+		next.names = append(slices.Clip(current.names), tag)
+	}
+	if t != nil {
+		next.types[t] = id
+	}
+	registry.Store(next)
+
+	return id
+}
+
+// With iterates the entities having a component for every one of ids.
+func (w *World) With(ids ...ComponentID) iter.Seq[Entity] {
+	return func(yield func(Entity) bool) {
+		for _, e := range w.entities {
+			if hasAll(e, ids) && !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Each iterates the entities having a T component, along with it.
+func Each[T Component](w *World) iter.Seq2[Entity, T] {
+	id := ComponentIDOf[T]()
+
+	return func(yield func(Entity, T) bool) {
+		for _, e := range w.entities {
+			if c, ok := e.Component(id).(T); ok && !yield(e, c) {
+				return
+			}
+		}
+	}
+}
+
+func hasAll(entity Entity, ids []ComponentID) bool {
+	for _, id := range ids {
+		if entity.Component(id) == nil {
+			return false
+		}
+	}
+
+	return true
+}
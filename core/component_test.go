@@ -0,0 +1,88 @@
+package core_test
+
+import (
+	"encoding/json"
+	"game/comps/body"
+	"game/comps/render"
+	"game/comps/stats"
+	"game/core"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func BenchmarkGet(b *testing.B) {
+	entity := &countingEntity{BaseEntity: &core.BaseEntity{}}
+	entity.Add(&body.Comp{}, &render.Comp{}, &stats.Comp{})
+	b.Run("registry", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if core.Get[*stats.Comp](entity) == nil {
+				b.Fatal("entity has no stats")
+			}
+		}
+	})
+	// The tag lookup Get did before the registry.
+	tags := map[string]core.Component{}
+	for _, c := range entity.Components() {
+		tags[reflect.TypeOf(c).String()] = c
+	}
+	b.Run("reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			var s *stats.Comp
+			if s, _ = tags[reflect.TypeOf(s).String()].(*stats.Comp); s == nil {
+				b.Fatal("entity has no stats")
+			}
+		}
+	})
+}
+
+// counterComp saves its count.
+type counterComp struct {
+	count int
+}
+
+func (c *counterComp) Init(_ core.Entity)                   {}
+func (c *counterComp) Update(_ float64)                     {}
+func (c *counterComp) Draw(_ *core.Pipeline, _ ebiten.GeoM) {}
+func (c *counterComp) Remove()                              {}
+func (c *counterComp) SaveState() any                       { return c.count }
+func (c *counterComp) LoadState(data json.RawMessage) error { return json.Unmarshal(data, &c.count) }
+
+func TestStatesByName(t *testing.T) {
+	world := core.NewWorld(320, 180)
+	entity := &countingEntity{BaseEntity: &core.BaseEntity{}}
+	entity.Add(&counterComp{count: 1})
+	entity.AddWithTag(&counterComp{count: 2}, "second")
+	world.Map = &core.Map{}
+	world.AddWithID(entity, 7)
+	world.Update(0)
+
+	states, err := world.SaveStates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys := slices.Sorted(maps.Keys(states[7])); !slices.Equal(keys, []string{"*core_test.counterComp", "second"}) {
+		t.Fatalf("state keys = %v, want the type name and the tag", keys)
+	}
+
+	loaded := &countingEntity{BaseEntity: &core.BaseEntity{}}
+	first, second := &counterComp{}, &counterComp{}
+	loaded.Add(first)
+	loaded.AddWithTag(second, "second")
+	world = core.NewWorld(320, 180)
+	world.Map = &core.Map{}
+	world.AddWithID(loaded, 7)
+	world.Update(0)
+	states[7]["stale"] = json.RawMessage(`3`)
+	if err := world.LoadStates(states); err != nil {
+		t.Fatal(err)
+	}
+	if first.count != 1 || second.count != 2 {
+		t.Errorf("counts loaded = %d, %d, want 1, 2 each under its own name", first.count, second.count)
+	}
+}
@@ -0,0 +1,27 @@
+// Package coretest has the bare entity and world the tests of components and entities run on.
+package coretest
+
+import (
+	"game/core"
+	"game/vars"
+)
+
+// Entity does nothing by itself, leaving the components added to it to be tested.
+type Entity struct {
+	*core.BaseEntity
+}
+
+func NewEntity(x, y, w, h float64) *Entity {
+	return &Entity{BaseEntity: &core.BaseEntity{X: x, Y: y, W: w, H: h}}
+}
+
+func (e *Entity) Init()            {}
+func (e *Entity) Update(_ float64) {}
+
+// NewWorld sets vars.World to a screen sized world with an empty map.
+func NewWorld() *core.World {
+	vars.World = core.NewWorld(float64(vars.ScreenWidth), float64(vars.ScreenHeight))
+	vars.World.Map = &core.Map{}
+
+	return vars.World
+}
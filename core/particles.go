@@ -0,0 +1,320 @@
+package core
+
+import (
+	"game/libs/bump"
+	"game/utils"
+	"image"
+	"image/color"
+	"math"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// MaxParticles is the size of the pool, emitting past it drops the new particles.
+const MaxParticles = 2048
+
+type EmitterShape int
+
+const (
+	PointShape EmitterShape = iota
+	RectShape
+	CircleShape
+)
+
+// Range is a random value between Min and Max.
+type Range = struct{ Min, Max float64 }
+
+// Emitter describes a burst of particles. The color and alpha curves are spread evenly over the particle life.
+type Emitter struct {
+	Image       *ebiten.Image // A square of Size pixels when nil.
+	Size        float64
+	Shape       EmitterShape
+	W, H        float64 // The rect size, W being the radius of a circle.
+	Count       int
+	Speed       Range
+	Angle       float64 // Direction in radians, -π/2 being up.
+	Spread      float64 // Random angle on each side of Angle.
+	Gravity     float64
+	Drag        float64 // Fraction of the speed lost per second.
+	Life        Range
+	Colors      []color.RGBA
+	Alpha       []float64
+	Collide     bool    // Stops on the map and solids instead of going through them.
+	Bounce      float64 // Fraction of the speed kept after colliding.
+	Layer       int
+	Attract     Range   // Seconds before flying to the EmitToward target.
+	AttractTime float64 // Seconds it takes to reach the target.
+	OnArrive    func(target Entity)
+}
+
+type particle struct {
+	emitter          *Emitter
+	target           Entity
+	x, y, vx, vy     float64
+	age, life        float64
+	attractAt        float64
+	startX, startY   float64
+	targetX, targetY float64 // Where in the target rect it flies to, in fractions of its size.
+	attracting       bool
+	arrived          bool
+}
+
+// Particles is a pool of small, short lived sprites the World updates and draws in batches, one draw per layer and
+// image instead of an Entity each.
+type Particles struct {
+	ImageTag, NormalMapTag string // The pipeline images the particles and their normal mask are drawn to.
+	particles              []particle
+	space                  *bump.Space
+	mutex                  sync.Mutex
+}
+
+type particleBatch struct {
+	layer int
+	image *ebiten.Image
+}
+
+var particleImage = func() *ebiten.Image {
+	img := ebiten.NewImage(3, 3) //nolint: mnd
+	img.Fill(color.White)
+
+	// The center pixel avoids bleeding from the image edges.
+	return img.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image) //nolint: forcetypeassert
+}()
+
+func NewParticles(space *bump.Space) *Particles {
+	return &Particles{particles: make([]particle, 0, MaxParticles), space: space}
+}
+
+// Emit spawns a burst of particles around x, y.
+func (p *Particles) Emit(emitter *Emitter, x, y float64) { p.EmitToward(emitter, x, y, nil) }
+
+// EmitToward spawns a burst of particles flying to target once their Attract time ends, calling OnArrive for
+// each one reaching it. A nil target makes it the same as Emit.
+func (p *Particles) EmitToward(emitter *Emitter, x, y float64, target Entity) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for range emitter.Count {
+		if len(p.particles) == cap(p.particles) {
+			return
+		}
+		px, py := emitterPoint(emitter)
+		angle := emitter.Angle + (utils.Rand.Float64()*2-1)*emitter.Spread
+		speed := randRange(emitter.Speed)
+		p.particles = append(p.particles, particle{
+			emitter: emitter,
+			target:  target,
+			x:       x + px, y: y + py,
+			vx: math.Cos(angle) * speed, vy: math.Sin(angle) * speed,
+			life:      randRange(emitter.Life),
+			attractAt: randRange(emitter.Attract),
+			targetX:   utils.Rand.Float64(), targetY: utils.Rand.Float64(),
+		})
+	}
+}
+
+func (p *Particles) Len() int { return len(p.particles) }
+
+func (p *Particles) Clear() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.particles = p.particles[:0]
+}
+
+func (p *Particles) Update(dt float64) {
+	p.mutex.Lock()
+	var arrived []particle
+	for i := 0; i < len(p.particles); {
+		if p.step(&p.particles[i], dt) {
+			i++
+
+			continue
+		}
+		if p.particles[i].arrived {
+			arrived = append(arrived, p.particles[i])
+		}
+		last := len(p.particles) - 1
+		p.particles[i] = p.particles[last]
+		p.particles = p.particles[:last]
+	}
+	p.mutex.Unlock()
+
+	// Called unlocked, arriving may emit more particles.
+	for _, pt := range arrived {
+		if pt.emitter.OnArrive != nil {
+			pt.emitter.OnArrive(pt.target)
+		}
+	}
+}
+
+// step moves a particle, returning false once it's dead.
+func (p *Particles) step(pt *particle, dt float64) bool {
+	e := pt.emitter
+	pt.age += dt
+	if pt.target != nil && pt.age >= pt.attractAt {
+		if !pt.attracting {
+			pt.attracting, pt.startX, pt.startY = true, pt.x, pt.y
+		}
+		prog := 1.0
+		if e.AttractTime > 0 {
+			prog = math.Min((pt.age-pt.attractAt)/e.AttractTime, 1)
+		}
+		tx, ty, tw, th := pt.target.Rect()
+		path := prog * prog
+		pt.x = pt.startX + path*(tx+tw*pt.targetX-pt.startX)
+		pt.y = pt.startY + path*(ty+th*pt.targetY-pt.startY)
+		pt.arrived = prog >= 1
+
+		return !pt.arrived
+	}
+	if pt.target == nil && pt.age >= pt.life {
+		return false
+	}
+
+	pt.vy += e.Gravity * dt
+	drag := math.Max(0, 1-e.Drag*dt)
+	pt.vx, pt.vy = pt.vx*drag, pt.vy*drag
+	if x := pt.x + pt.vx*dt; !e.Collide || !p.solidAt(x, pt.y) {
+		pt.x = x
+	} else {
+		pt.vx, pt.vy = -pt.vx*e.Bounce, pt.vy*e.Bounce
+	}
+	if y := pt.y + pt.vy*dt; !e.Collide || !p.solidAt(pt.x, y) {
+		pt.y = y
+	} else {
+		pt.vx, pt.vy = pt.vx*e.Bounce, -pt.vy*e.Bounce
+	}
+
+	return true
+}
+
+func (p *Particles) solidAt(x, y float64) bool {
+	cols := p.space.Query(bump.NewRect(x, y, 1, 1), func(item bump.Item) bool {
+		return !p.space.Has(item, "slope") && !p.space.Has(item, "passthrough")
+	}, "map", "solid")
+
+	return len(cols) > 0
+}
+
+func (p *Particles) Draw(pipeline *Pipeline, cx, cy float64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if len(p.particles) == 0 {
+		return
+	}
+	batches := map[particleBatch][]ebiten.Vertex{}
+	var order []particleBatch
+	for i := range p.particles {
+		pt := &p.particles[i]
+		batch := particleBatch{pt.emitter.Layer, pt.emitter.Image}
+		if batch.image == nil {
+			batch.image = particleImage
+		}
+		if _, ok := batches[batch]; !ok {
+			order = append(order, batch)
+		}
+		batches[batch] = appendQuad(batches[batch], pt, batch.image, math.Floor(pt.x-cx), math.Floor(pt.y-cy))
+	}
+	for _, batch := range order {
+		vertices := batches[batch]
+		indices := quadIndices(len(vertices) / 4) //nolint: mnd
+		pipeline.Add(p.NormalMapTag, batch.layer, func(normalMap *ebiten.Image) {
+			// Masks the normals behind the particles like sprites do, see anim.FillNormalMaskColorM.
+			masked := make([]ebiten.Vertex, len(vertices))
+			for i, v := range vertices {
+				v.ColorR, v.ColorG, v.ColorB = 0, 0, 0
+				masked[i] = v
+			}
+			normalMap.DrawTriangles(masked, indices, batch.image, nil)
+		})
+		pipeline.Add(p.ImageTag, batch.layer, func(screen *ebiten.Image) {
+			screen.DrawTriangles(vertices, indices, batch.image, nil)
+		})
+	}
+}
+
+func appendQuad(vertices []ebiten.Vertex, pt *particle, img *ebiten.Image, x, y float64) []ebiten.Vertex {
+	e := pt.emitter
+	bounds := img.Bounds()
+	w, h := float64(bounds.Dx()), float64(bounds.Dy())
+	if e.Image == nil {
+		w, h = math.Max(e.Size, 1), math.Max(e.Size, 1)
+	}
+	prog := 0.0
+	if pt.target == nil && pt.life > 0 {
+		prog = math.Min(pt.age/pt.life, 1)
+	}
+	r, g, b := colorAt(e.Colors, prog)
+	a := 1.0
+	if len(e.Alpha) > 0 {
+		a = curveAt(e.Alpha, prog)
+	}
+	x1, y1 := float32(x-math.Floor(w/2)), float32(y-math.Floor(h/2))
+	x2, y2 := x1+float32(w), y1+float32(h)
+	sx1, sy1 := float32(bounds.Min.X), float32(bounds.Min.Y)
+	sx2, sy2 := float32(bounds.Max.X), float32(bounds.Max.Y)
+	// Premultiplied alpha.
+	cr, cg, cb, ca := float32(r*a), float32(g*a), float32(b*a), float32(a)
+
+	return append(vertices,
+		ebiten.Vertex{DstX: x1, DstY: y1, SrcX: sx1, SrcY: sy1, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+		ebiten.Vertex{DstX: x2, DstY: y1, SrcX: sx2, SrcY: sy1, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+		ebiten.Vertex{DstX: x1, DstY: y2, SrcX: sx1, SrcY: sy2, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+		ebiten.Vertex{DstX: x2, DstY: y2, SrcX: sx2, SrcY: sy2, ColorR: cr, ColorG: cg, ColorB: cb, ColorA: ca},
+	)
+}
+
+func quadIndices(quads int) []uint16 {
+	indices := make([]uint16, 0, quads*6) //nolint: mnd
+	for i := range quads {
+		n := uint16(i * 4) //nolint: mnd, gosec
+		indices = append(indices, n, n+1, n+2, n+1, n+3, n+2)
+	}
+
+	return indices
+}
+
+func emitterPoint(e *Emitter) (float64, float64) {
+	switch e.Shape {
+	case RectShape:
+		return (utils.Rand.Float64() - 0.5) * e.W, (utils.Rand.Float64() - 0.5) * e.H
+	case CircleShape:
+		angle, dist := utils.Rand.Float64()*2*math.Pi, math.Sqrt(utils.Rand.Float64())*e.W
+
+		return math.Cos(angle) * dist, math.Sin(angle) * dist
+	default:
+		return 0, 0
+	}
+}
+
+// curveAt interpolates the values of a curve evenly spread over prog, from 0 to 1.
+func curveAt(curve []float64, prog float64) float64 {
+	if len(curve) == 1 {
+		return curve[0]
+	}
+	pos := prog * float64(len(curve)-1)
+	i := min(int(pos), len(curve)-2)
+	t := pos - float64(i)
+
+	return curve[i] + (curve[i+1]-curve[i])*t
+}
+
+func colorAt(colors []color.RGBA, prog float64) (float64, float64, float64) {
+	if len(colors) == 0 {
+		return 1, 1, 1
+	}
+	c1, c2, t := colors[0], colors[0], 0.0
+	if len(colors) > 1 {
+		pos := prog * float64(len(colors)-1)
+		i := min(int(pos), len(colors)-2)
+		c1, c2, t = colors[i], colors[i+1], pos-float64(i)
+	}
+	channel := func(v1, v2 uint8) float64 { return (float64(v1) + (float64(v2)-float64(v1))*t) / math.MaxUint8 }
+
+	return channel(c1.R, c2.R), channel(c1.G, c2.G), channel(c1.B, c2.B)
+}
+
+func randRange(r Range) float64 { return r.Min + utils.Rand.Float64()*(r.Max-r.Min) }
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+const entityStateKey = "entity"
+
+// Persister is an entity or component that keeps its own state in the save, under the ID of its entity.
+type Persister interface {
+	// SaveState returns the state to keep, or nil when there's nothing worth saving.
+	SaveState() any
+	LoadState(state json.RawMessage) error
+}
+
+// EntityState holds the state of an entity and its components, keyed by "entity" and the names their IDs were
+// registered under.
+type EntityState map[string]json.RawMessage
+
+// SaveStates collects the state of every Persister in entities with an ID, removed ones included.
+func (w *World) SaveStates() (map[uint]EntityState, error) {
+	states := map[uint]EntityState{}
+	for _, entity := range slices.Concat(w.entities, w.removed) {
+		id := w.entityToID[entity]
+		if id == 0 {
+			continue
+		}
+		state := EntityState{}
+		if persister, ok := entity.(Persister); ok {
+			if err := state.add(entityStateKey, persister); err != nil {
+				return nil, fmt.Errorf("world: saving state of %d: %w", id, err)
+			}
+		}
+		names := registry.Load().names
+		for _, componentID := range entity.ComponentIDs() {
+			if persister, ok := entity.Component(componentID).(Persister); ok {
+				if err := state.add(names[componentID], persister); err != nil {
+					return nil, fmt.Errorf("world: saving state of %d: %w", id, err)
+				}
+			}
+		}
+		if len(state) > 0 {
+			states[id] = state
+		}
+	}
+
+	return states, nil
+}
+
+// LoadStates hands every saved state back to its Persister, ignoring the IDs no longer in the world.
+func (w *World) LoadStates(states map[uint]EntityState) error {
+	for _, id := range slices.Sorted(maps.Keys(states)) {
+		entity := w.idToEntity[id]
+		if entity == nil {
+			continue
+		}
+		for _, key := range slices.Sorted(maps.Keys(states[id])) {
+			persister, _ := entity.(Persister)
+			if key != entityStateKey {
+				componentID, ok := lookupTag(key)
+				if !ok {
+					continue
+				}
+				persister, _ = entity.Component(componentID).(Persister)
+			}
+			if persister == nil {
+				continue
+			}
+			if err := persister.LoadState(states[id][key]); err != nil {
+				return fmt.Errorf("world: loading state of %d: %w", id, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// NewEntityState is the state of an entity that isn't in the world, keyed as its own state.
+func NewEntityState(persister Persister) (EntityState, error) {
+	state := EntityState{}
+
+	return state, state.add(entityStateKey, persister)
+}
+
+func (s EntityState) add(key string, persister Persister) error {
+	state := persister.SaveState()
+	if state == nil {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	s[key] = data
+
+	return err
+}
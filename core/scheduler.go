@@ -0,0 +1,125 @@
+package core
+
+import (
+	"iter"
+	"slices"
+)
+
+// Sequence is a coroutine run by the Scheduler, each value it yields being the seconds to wait before resuming it.
+type Sequence = iter.Seq[float64]
+
+// Scheduler runs delayed, repeated and Sequence tasks on World time, so they follow its Speed, stop while it's frozen
+// or paused and always run on the game loop.
+type Scheduler struct {
+	tasks []*Task
+	now   float64
+}
+
+// Task is a scheduled callback or Sequence, cancelled along with its owner entity when it's removed from the World.
+type Task struct {
+	owner     Entity
+	at, every float64
+	callback  func()
+	next      func() (float64, bool)
+	stop      func()
+	done      bool
+	scheduler *Scheduler
+}
+
+func NewScheduler() *Scheduler { return &Scheduler{} }
+
+// After calls callback once delay seconds have passed. The owner may be nil for tasks outliving every entity.
+func (s *Scheduler) After(owner Entity, delay float64, callback func()) *Task {
+	return s.add(&Task{owner: owner, at: s.now + delay, callback: callback})
+}
+
+// Every calls callback each interval seconds until cancelled.
+func (s *Scheduler) Every(owner Entity, interval float64, callback func()) *Task {
+	return s.add(&Task{owner: owner, at: s.now + interval, every: interval, callback: callback})
+}
+
+// Run starts sequence right away, resuming it after each wait it yields until it returns.
+func (s *Scheduler) Run(owner Entity, sequence Sequence) *Task {
+	task := &Task{owner: owner, scheduler: s}
+	task.next, task.stop = iter.Pull(sequence)
+	task.resume()
+	if task.done {
+		return task
+	}
+
+	return s.add(task)
+}
+
+// Cancel stops every task of owner.
+func (s *Scheduler) Cancel(owner Entity) {
+	if owner == nil {
+		return
+	}
+	for _, task := range s.tasks {
+		if task.owner == owner {
+			task.Cancel()
+		}
+	}
+}
+
+// Clear stops every task.
+func (s *Scheduler) Clear() {
+	for _, task := range s.tasks {
+		task.Cancel()
+	}
+	s.tasks = nil
+}
+
+func (s *Scheduler) Len() int { return len(s.tasks) }
+
+func (s *Scheduler) Update(dt float64) {
+	s.now += dt
+	// Tasks added by the ones running wait for the next update.
+	for i, count := 0, len(s.tasks); i < count && i < len(s.tasks); i++ {
+		task := s.tasks[i]
+		if task.done || s.now < task.at {
+			continue
+		}
+		switch {
+		case task.next != nil:
+			task.resume()
+		case task.every > 0:
+			task.at += task.every
+			task.callback()
+		default:
+			task.done = true
+			task.callback()
+		}
+	}
+	s.tasks = slices.DeleteFunc(s.tasks, func(task *Task) bool { return task.done })
+}
+
+func (s *Scheduler) add(task *Task) *Task {
+	task.scheduler = s
+	s.tasks = append(s.tasks, task)
+
+	return task
+}
+
+// Cancel stops the task, it's safe to call on a nil or finished task.
+func (t *Task) Cancel() {
+	if t == nil || t.done {
+		return
+	}
+	t.done = true
+	if t.stop != nil {
+		t.stop()
+	}
+}
+
+func (t *Task) Done() bool { return t == nil || t.done }
+
+func (t *Task) resume() {
+	wait, ok := t.next()
+	if !ok {
+		t.done = true
+
+		return
+	}
+	t.at = t.scheduler.now + wait
+}
@@ -0,0 +1,33 @@
+package core_test
+
+import (
+	"game/core"
+	"slices"
+	"testing"
+)
+
+func TestScheduler(t *testing.T) {
+	scheduler := core.NewScheduler()
+	var calls []string
+	scheduler.After(nil, 0.5, func() { calls = append(calls, "after") })
+	every := scheduler.Every(nil, 0.3, func() { calls = append(calls, "every") })
+	scheduler.Run(nil, func(yield func(float64) bool) {
+		calls = append(calls, "start")
+		if yield(0.4) {
+			calls = append(calls, "resume")
+		}
+	})
+	for range 4 {
+		scheduler.Update(0.2)
+	}
+	every.Cancel()
+	scheduler.Update(1)
+
+	want := []string{"start", "every", "resume", "after", "every"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if scheduler.Len() != 0 {
+		t.Errorf("%d tasks left", scheduler.Len())
+	}
+}
@@ -18,7 +18,11 @@ import (
 	"github.com/lafriks/go-tiled/render"
 )
 
-const viewPropName = "view"
+const (
+	viewPropName       = "view"
+	activationPropName = "activation"
+)
+
 const secondToMillisecond = 1000
 
 var (
@@ -94,6 +98,8 @@ func RegisterEntityName[T Entity](name string, constructor func(x, y, w, h float
 	entityObjects[name] = func(x, y, w, h float64, p *Properties) Entity { return constructor(x, y, w, h, p) }
 }
 
+func EntityByName(name string) EntityContructor { return entityObjects[name] }
+
 func NewMap(mapPath string, backLayersNum int, fs fs.FS, drawImagesTags ...string) *Map {
 	data, err := tiled.LoadFile(mapPath, tiled.WithFileSystem(fs))
 	if err != nil {
@@ -128,6 +134,22 @@ func NewMap(mapPath string, backLayersNum int, fs fs.FS, drawImagesTags ...strin
 	return m
 }
 
+// Deallocate frees the rendered layer images, once the map is left for good.
+func (m *Map) Deallocate() {
+	for _, layers := range m.layers {
+		for _, layer := range layers {
+			if layer.image != nil {
+				layer.image.Deallocate()
+			}
+		}
+	}
+	for _, layer := range m.objectLayers {
+		if layer.image != nil {
+			layer.image.Deallocate()
+		}
+	}
+}
+
 func (m *Map) Update(dt float64) {
 	for _, layers := range m.layers {
 		for _, layer := range layers {
@@ -436,9 +458,19 @@ func (m *Map) LoadEntityObjects(world *World, objectGroupName string, entityBind
 			}
 		}
 		entity := construct(obj.X, obj.Y, obj.Width, obj.Height, props)
+		if entity == nil {
+			continue
+		}
 		x, y, _, h := entity.Rect()
 		entity.SetPosition(x, y+obj.Height-h)
 		world.AddWithID(entity, uint(obj.ID))
+		if name, ok := props.Custom[activationPropName]; ok {
+			activation, err := ParseActivation(name)
+			if err != nil {
+				log.Printf("Warning: entity %d: %v\n", obj.ID, err)
+			}
+			world.SetActivation(entity, activation)
+		}
 
 		/*
 			TODO: Adjust the X when flipped too
@@ -1,11 +1,12 @@
 package core
 
 import (
+	"cmp"
 	"game/libs/bump"
 	"game/libs/camera"
+	"game/libs/nav"
 	"log"
 	"math"
-	"reflect"
 	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -15,7 +16,8 @@ type Entity interface {
 	Init()
 	Update(dt float64)
 	Components() []Component
-	Component(name string) Component
+	ComponentIDs() []ComponentID
+	Component(id ComponentID) Component
 	Position() (float64, float64)
 	SetPosition(x, y float64)
 	Rect() (float64, float64, float64, float64)
@@ -31,6 +33,10 @@ type Component interface {
 
 type World struct {
 	Space      *bump.Space
+	Nav        *nav.Graph
+	Particles  *Particles
+	Scheduler  *Scheduler
+	Events     *Bus
 	Camera     *camera.Camera
 	Speed      float64
 	Map        *Map
@@ -41,17 +47,27 @@ type World struct {
 	toRemove   []Entity
 	removed    []Entity
 	mutex      sync.Mutex
+	room       *bump.Rect
 
+	activations map[Entity]*activation
 	freezeTimer float64
+	elapsed     float64
 }
 
 func NewWorld(width, height float64) *World {
+	space := bump.NewSpace()
+
 	return &World{
-		Space:      bump.NewSpace(),
+		Space:      space,
+		Particles:  NewParticles(space),
+		Scheduler:  NewScheduler(),
+		Events:     NewBus(),
 		Camera:     camera.New(width, height),
 		Speed:      1,
 		idToEntity: map[uint]Entity{},
 		entityToID: map[Entity]uint{},
+
+		activations: map[Entity]*activation{},
 	}
 }
 
@@ -84,13 +100,20 @@ func (w *World) Update(dt float64) {
 	w.mutex.Unlock()
 
 	dt *= w.Speed
+	w.elapsed = 0
 	w.Camera.Update(dt)
+	if room := w.Camera.Room(); room != nil && room != w.room {
+		w.resetLeftRooms(w.room)
+		w.room = room
+		Publish(w.Events, RoomEntered{Room: *room})
+	}
 	if w.freezeTimer -= dt; w.freezeTimer >= 0 {
 		return
 	}
+	w.elapsed = dt
 	w.Map.Update(dt)
 	for _, e := range w.entities {
-		if !w.Camera.InFrame(e, 1, 1) {
+		if !w.active(e) {
 			continue
 		}
 		for _, c := range e.Components() {
@@ -98,6 +121,8 @@ func (w *World) Update(dt float64) {
 		}
 		e.Update(dt)
 	}
+	w.Scheduler.Update(dt)
+	w.Particles.Update(dt)
 
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
@@ -109,6 +134,8 @@ func (w *World) Update(dt float64) {
 			for _, c := range e.Components() {
 				c.Remove()
 			}
+			w.Scheduler.Cancel(e)
+			delete(w.activations, e)
 			w.entities[i] = w.entities[len(w.entities)-1]
 			w.entities = w.entities[:len(w.entities)-1]
 			w.removed = append(w.removed, e)
@@ -134,6 +161,7 @@ func (w *World) Draw(pipeline *Pipeline) {
 			c.Draw(pipeline, entityPos)
 		}
 	}
+	w.Particles.Draw(pipeline, cx, cy)
 }
 
 func (w *World) SetMap(tiledMap *Map, roomsLayer string) {
@@ -152,16 +180,17 @@ func (w *World) GetID(entity Entity) uint { return w.entityToID[entity] }
 func (w *World) GetAll() []Entity         { return w.entities }
 func (w *World) GetRemoved() []Entity     { return w.removed }
 
+// CompareIDs orders entities by their ID, the ones added without one coming first.
+func (w *World) CompareIDs(a, b Entity) int { return cmp.Compare(w.GetID(a), w.GetID(b)) }
+
 func Get[T Component](entity Entity) T {
-	var t T
-	tag := reflect.TypeOf(t).String()
-	t, _ = entity.Component(tag).(T)
+	t, _ := entity.Component(ComponentIDOf[T]()).(T)
 
 	return t
 }
 
 func GetWithTag[T Component](entity Entity, tag string) T {
-	t, _ := entity.Component(tag).(T)
+	t, _ := entity.Component(TagID(tag)).(T)
 
 	return t
 }
@@ -195,8 +224,30 @@ func (w *World) RemoveAll() {
 		}
 	}
 	w.entities = nil
+	w.Scheduler.Clear()
+	w.Particles.Clear()
 	w.idToEntity = map[uint]Entity{}
 	w.entityToID = map[Entity]uint{}
+	w.activations = map[Entity]*activation{}
+}
+
+// Unload removes every entity along with the map and its collisions, leaving an empty Space for the next SetMap.
+func (w *World) Unload() {
+	w.RemoveAll()
+	w.mutex.Lock()
+	w.toInit, w.toRemove, w.removed = nil, nil, nil
+	w.mutex.Unlock()
+
+	w.Space = bump.NewSpace()
+	w.Particles.space = w.Space
+	if w.Map != nil {
+		w.Map.Deallocate()
+	}
+	w.Map, w.Nav, w.room = nil, nil, nil
+	w.freezeTimer = 0
 }
 
 func (w *World) Freeze(time float64) { w.freezeTimer = time }
+
+// Elapsed is the game time the last Update went through, scaled by Speed and none while frozen.
+func (w *World) Elapsed() float64 { return w.elapsed }
@@ -0,0 +1,21 @@
+package core_test
+
+import (
+	"game/core"
+	"testing"
+)
+
+func TestElapsed(t *testing.T) {
+	world := core.NewWorld(320, 180)
+	world.Map = &core.Map{}
+	world.Speed = 0.5
+	world.Update(0.2)
+	if elapsed := world.Elapsed(); elapsed != 0.1 {
+		t.Errorf("elapsed = %v at half speed, want 0.1", elapsed)
+	}
+	world.Freeze(1)
+	world.Update(0.2)
+	if elapsed := world.Elapsed(); elapsed != 0 {
+		t.Errorf("elapsed = %v while frozen, want 0", elapsed)
+	}
+}
@@ -0,0 +1,8 @@
+package enemies
+
+import "embed"
+
+// FS holds the enemy definitions shipped with the game, along with the relations of their factions.
+//
+//go:embed *.json
+var FS embed.FS
@@ -17,12 +17,13 @@ import (
 )
 
 const (
-	dieSeconds   = 1
-	flashSeconds = 0.05
+	dieSeconds        = 1
+	flashSeconds      = 0.05
+	attackNoiseRadius = 80
+	landNoiseRadius   = 60
+	landNoiseSpeed    = 150
 )
 
-var DieParticle func(core.Entity) core.Entity
-
 type Actor interface {
 	core.Entity
 	Comps() (anim *anim.Comp, body *body.Comp, hitbox *hitbox.Comp, stats *stats.Comp, ai *ai.Comp)
@@ -36,6 +37,7 @@ type Control struct {
 	stats                *stats.Comp
 	ai                   *ai.Comp
 	Paused               bool
+	pathing              bool
 	dieTimer, flashTimer float64
 }
 
@@ -52,6 +54,9 @@ func (c *Control) Init() {
 		log.Panicf("actor: no hurtbox found: %s", err)
 	}
 	c.hitbox.PushHitbox(hurtbox, hitbox.Hit, nil)
+	if c.anim.Sounds == nil {
+		c.anim.Sounds = map[string]map[int]string{vars.WalkTag: {0: "step"}}
+	}
 	c.hitbox.HitFunc = func(other core.Entity, _ *bump.Collision, damage float64, contactType hitbox.ContactType) {
 		switch contactType {
 		case hitbox.Hit:
@@ -72,15 +77,28 @@ func (c *Control) SimpleUpdate(dt float64) {
 	if c.flashTimer -= dt; c.flashTimer > 0 {
 		c.anim.ColorScale = anim.WhiteScalerColor
 	}
+	if c.body.LandSpeed >= landDustSpeed {
+		x, y, w, h := c.actor.Rect()
+		vars.World.Particles.Emit(Dust, x+w/2, y+h)
+		vars.Audio.PlayAt("land", x+w/2, y+h)
+	}
+	if c.body.LandSpeed >= landNoiseSpeed {
+		ai.MakeNoise(c.actor, landNoiseRadius)
+	}
 	c.stats.Pause = c.PausingState()
+	c.body.SpeedMult = c.stats.SpeedMult()
 	if state := c.anim.State; state == vars.IdleTag || state == vars.WalkTag {
 		nextState := vars.IdleTag
 		if c.body.Vx != 0 {
 			nextState = vars.WalkTag
 		}
 		c.anim.SetState(nextState)
-		if c.ai != nil && c.ai.Target != nil {
-			tx, _, tw, _ := c.ai.Target.Rect()
+		if c.pathing && c.body.Vx != 0 {
+			// Following a path faces where the actor goes, not always the target.
+			c.anim.FlipX = c.body.Vx > 0
+		} else if c.ai != nil && c.ai.Target != nil {
+			tx, _ := c.ai.TargetPosition()
+			_, _, tw, _ := c.ai.Target.Rect()
 			x, _, w, _ := c.actor.Rect()
 			c.anim.FlipX = tx+tw/2 > x+w/2
 		}
@@ -89,6 +107,7 @@ func (c *Control) SimpleUpdate(dt float64) {
 
 func (c *Control) Hurt(other core.Entity, damage, reactForce float64) {
 	// TODO: Figure out force stuff here. For Block() too.
+	core.Publish(vars.World.Events, core.EntityDamaged{Entity: c.actor, Source: other, Damage: damage})
 	c.ShieldDown()
 	c.stats.AddPoise(-damage)
 	c.stats.AddHealth(-damage)
@@ -105,18 +124,24 @@ func (c *Control) Hurt(other core.Entity, damage, reactForce float64) {
 		c.Stagger(force, false, 1)
 	}
 	c.flashTimer = flashSeconds
+	Emit(Blood, c.actor)
+	c.playSound("hit")
 
 	if c.ai != nil && c.ai.Target == nil {
-		c.ai.Target = other
+		c.ai.Notice(other)
 	}
 }
 
 func (c *Control) Block(other core.Entity, damage, reactForce float64, contactType hitbox.ContactType) {
 	c.stats.AddHealth(-damage / 10)
 	c.stats.AddStamina(-damage)
+	Emit(HitSparks, c.actor)
 	if contactType == hitbox.ParryBlock {
+		c.playSound("parry")
+
 		return
 	}
+	c.playSound("block")
 
 	force := reactForce / 2
 	ax, _ := c.actor.Position()
@@ -143,11 +168,14 @@ func (c *Control) Die(dt float64) {
 	}
 
 	vars.World.Remove(c.actor)
-	if DieParticle != nil {
-		for range c.stats.Exp {
-			vars.World.Add(DieParticle(c.actor))
-		}
-	}
+	Emit(DeathBurst, c.actor)
+	c.playSound("death")
+	core.Publish(vars.World.Events, core.EntityDied{Entity: c.actor, Exp: c.stats.Exp})
+}
+
+func (c *Control) playSound(name string) {
+	x, y, w, h := c.actor.Rect()
+	vars.Audio.PlayAt(name, x+w/2, y+h/2)
 }
 
 func (c *Control) Attack(attackTag string, damage, staminaDamage, reactForce, pushForce float64) {
@@ -160,7 +188,7 @@ func (c *Control) MultAttack(attackTag string, damage, staminaDamage, reactForce
 	if c.PausingState() || c.stats.Stamina <= 0 {
 		return
 	}
-	damage *= 1 + c.stats.AttackMult
+	damage *= (1 + c.stats.AttackMult) * c.stats.DamageMult()
 	if strings.HasPrefix(c.anim.State, attackTag) && c.anim.Data.Animation(c.anim.State+"C") != nil {
 		attackTag = c.anim.State + "C"
 	}
@@ -180,19 +208,19 @@ func (c *Control) MultAttack(attackTag string, damage, staminaDamage, reactForce
 
 	var contactType hitbox.ContactType
 	var contacted []*hitbox.Comp
-	var shakeNum int
+	var hits int
 	var once bool
 	c.anim.OnSlicePresent(vars.HitboxSliceName, func(slice bump.Rect, segmented bool) {
 		if segmented {
 			contacted = nil
-			shakeNum = 0
+			hits = 0
 		}
 		attackMult := *mult + 1
 		totalDamage := damage * attackMult
 		contactType, contacted = c.hitbox.HitFromHitBox(slice, totalDamage, contacted)
-		if c.actor == vars.Player && shakeNum != len(contacted) { // TODO: This is an ugly hack
-			shakeNum = len(contacted)
-			vars.World.Camera.Shake(0.1*float32(attackMult), 0.5*(attackMult))
+		if hits != len(contacted) {
+			hits = len(contacted)
+			core.Publish(vars.World.Events, core.HitLanded{Attacker: c.actor, Hits: hits, Mult: attackMult})
 		}
 		if contactType == hitbox.ParryBlock {
 			if c.stats.AddPoise(-totalDamage); c.stats.Poise <= 0 {
@@ -201,6 +229,8 @@ func (c *Control) MultAttack(attackTag string, damage, staminaDamage, reactForce
 		}
 		if !once {
 			once = true
+			ai.MakeNoise(c.actor, attackNoiseRadius)
+			c.playSound("swing")
 			c.stats.AddStamina(-staminaDamage * attackMult)
 			force := pushForce
 			if contactType >= hitbox.Block {
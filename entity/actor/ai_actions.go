@@ -2,10 +2,12 @@ package actor
 
 import (
 	"game/comps/ai"
-	"game/core"
+	"game/comps/faction"
 	"game/ext"
 	"game/libs/bump"
+	"game/libs/nav"
 	"game/vars"
+	"math"
 )
 
 var (
@@ -15,6 +17,10 @@ var (
 
 	reactForce = 10.0
 	pushForce  = 10.0
+
+	pathReplanSeconds = 0.5
+	pathTakeOffRange  = 2.0
+	pathJumpMargin    = 4.0
 )
 
 func EntryAction(entry func()) *ai.Action {
@@ -49,8 +55,8 @@ func IdleAction(a *Control, view *bump.Rect) *ai.Action {
 				a.ai.DebugRect = view
 			}
 			for _, target := range targets {
-				if core.GetFlag(target, vars.PlayerTeamFlag) {
-					a.ai.Target = target
+				if faction.Hostile(a.actor, target) && a.ai.Sees(target) {
+					a.ai.Notice(target)
 
 					return true
 				}
@@ -165,3 +171,82 @@ func BackUpAction(a *Control, speed, maxSpeed float64) *ai.Action {
 		},
 	}
 }
+
+// PathFollowAction chases the target across platforms using the world navigation graph, jumping and dropping
+// between them. It walks straight at the target when there's no graph or no known path.
+func PathFollowAction(a *Control, speed, maxSpeed, rangeAdjustment float64) *ai.Action {
+	currentMaxSpeed := a.body.MaxX
+	var path []*nav.Link
+	var jumping *nav.Link
+	var replanTimer float64
+
+	return &ai.Action{
+		Name: "PathFollow",
+		Entry: func() {
+			a.body.MaxX = maxSpeed
+			a.pathing, path, jumping, replanTimer = true, nil, nil, 0
+		},
+		Exit: func() {
+			a.body.MaxX = currentMaxSpeed
+			a.pathing = false
+		},
+		Next: func(dt float64) bool {
+			if a.ai.Target == nil || a.ai.InTargetRange(0, ApproachMinTargetRange+rangeAdjustment) {
+				return true
+			}
+			if a.PausingState() {
+				return false
+			}
+			x, y, w, h := a.actor.Rect()
+			cx, feet := x+w/2, y+h
+			graph := vars.World.Nav
+			if !a.body.Ground {
+				if jumping != nil {
+					a.body.Vx += math.Copysign(speed*dt, jumping.ToX-cx)
+				}
+
+				return false
+			}
+			jumping = nil
+
+			tx, ty := a.ai.TargetPosition()
+			_, _, tw, th := a.ai.Target.Rect()
+			if replanTimer -= dt; graph != nil && replanTimer <= 0 {
+				replanTimer = pathReplanSeconds
+				// A target in the air keeps the last path, one out of reach gets approached straight.
+				if newPath, ok := graph.Path(cx, feet, tx+tw/2, ty+th); ok || graph.SurfaceAt(tx+tw/2, ty+th) != nil {
+					path = newPath
+				}
+			}
+			if graph != nil && len(path) > 0 && graph.SurfaceAt(cx, feet) == path[0].To {
+				path = path[1:]
+			}
+			if len(path) == 0 {
+				a.body.Vx += math.Copysign(speed*dt, tx+tw/2-cx)
+
+				return false
+			}
+
+			link := path[0]
+			if dx := link.FromX - cx; math.Abs(dx) > pathTakeOffRange {
+				a.body.Vx += math.Copysign(speed*dt, dx)
+
+				return false
+			}
+			switch link.Type {
+			case nav.Jump:
+				height := math.Max(0, link.From.Y-link.To.Y) + pathJumpMargin
+				a.body.Vy = -math.Min(math.Sqrt(2*vars.Gravity*height), vars.DefaultJumpSpeed)
+				a.body.Vx = math.Copysign(maxSpeed, link.ToX-cx)
+				jumping = link
+			case nav.Drop:
+				a.body.Vx += math.Copysign(speed*dt, link.ToX-cx)
+			case nav.DropThrough:
+				a.body.DropThrough()
+			}
+			replanTimer = 0
+
+			return false
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package actor
+
+import (
+	"game/core"
+	"game/vars"
+	"image/color"
+	"math"
+)
+
+const landDustSpeed = 100
+
+var (
+	HitSparks = &core.Emitter{
+		Count: 6, Size: 1, Shape: core.CircleShape, W: 2,
+		Speed: core.Range{60, 120}, Angle: -math.Pi / 2, Spread: math.Pi,
+		Drag: 6, Life: core.Range{0.1, 0.25},
+		Colors: []color.RGBA{{255, 255, 255, 255}, {251, 242, 54, 255}, {223, 113, 38, 255}},
+		Alpha:  []float64{1, 1, 0},
+		Layer:  1,
+	}
+	Blood = &core.Emitter{
+		Count: 8, Size: 1, Shape: core.CircleShape, W: 3,
+		Speed: core.Range{30, 90}, Angle: -math.Pi / 2, Spread: math.Pi / 3,
+		Gravity: vars.Gravity, Life: core.Range{0.4, 0.8},
+		Colors:  []color.RGBA{{172, 50, 50, 255}, {89, 13, 23, 255}},
+		Alpha:   []float64{1, 1, 0},
+		Collide: true, Layer: 1,
+	}
+	Dust = &core.Emitter{
+		Count: 6, Size: 1, Shape: core.RectShape, W: 6,
+		Speed: core.Range{10, 40}, Angle: -math.Pi / 2, Spread: math.Pi / 2,
+		Gravity: -vars.Gravity / 10, Drag: 4, Life: core.Range{0.3, 0.5},
+		Colors: []color.RGBA{{155, 173, 183, 255}},
+		Alpha:  []float64{0.8, 0},
+	}
+	DeathBurst = &core.Emitter{
+		Count: 20, Size: 1, Shape: core.CircleShape, W: 6,
+		Speed: core.Range{20, 80}, Angle: -math.Pi / 2, Spread: math.Pi,
+		Gravity: vars.Gravity / 2, Drag: 2, Life: core.Range{0.5, 1},
+		Colors:  []color.RGBA{{255, 255, 255, 255}, {132, 126, 135, 255}},
+		Alpha:   []float64{1, 0.8, 0},
+		Collide: true, Bounce: 0.3, Layer: 1,
+	}
+)
+
+// Emit bursts emitter from the center of entity.
+func Emit(emitter *core.Emitter, entity core.Entity) {
+	x, y, w, h := entity.Rect()
+	vars.World.Particles.Emit(emitter, x+w/2, y+h/2)
+}
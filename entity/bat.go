@@ -2,10 +2,6 @@ package entity
 
 import (
 	"game/comps/ai"
-	"game/comps/anim"
-	"game/comps/body"
-	"game/comps/hitbox"
-	"game/comps/stats"
 	"game/core"
 	"game/entity/actor"
 	"game/libs/bump"
@@ -13,111 +9,63 @@ import (
 	"math"
 )
 
-const (
-	batWidth, batHeight                   = 5, 5
-	batOffsetX, batOffsetY, batOffsetFlip = -10, -9, 9
-	batSpeed, batMaxSpeed                 = 60.0, 40
-	batHealth                             = 25
-	batDamage                             = 15
-	batExp                                = 15
-	batPoise                              = 10
-	batHeightView                         = 80
-)
+const batHeightView = 80
 
-type Bat struct {
-	*core.BaseEntity
-	*actor.Control
-	anim   *anim.Comp
-	body   *body.Comp
-	hitbox *hitbox.Comp
-	stats  *stats.Comp
-	ai     *ai.Comp
-	awake  bool
-}
+func init() { RegisterEnemyHooks("bat", newBatHooks, "stalk", "swoop") }
 
+// newBatHooks keeps the bat hanging weightless until it notices a target, then flying after it. Without a view it
+// watches the column below it.
 // TODO: Bats do not attack yet
-func NewBat(x, y, _, _ float64, props *core.Properties) *Bat {
-	bat := &Bat{
-		BaseEntity: &core.BaseEntity{X: x, Y: y, W: batWidth, H: batHeight},
-		anim: &anim.Comp{
-			FilesName: "bat",
-			OX:        batOffsetX, OY: batOffsetY,
-			OXFlip: batOffsetFlip,
-			FlipX:  props.FlipX,
-		},
-		body:   &body.Comp{},
-		hitbox: &hitbox.Comp{},
-		stats:  &stats.Comp{MaxHealth: batHealth, MaxPoise: batPoise, Exp: batExp},
-		ai:     &ai.Comp{},
-	}
-	bat.Add(bat.anim, bat.body, bat.hitbox, bat.stats, bat.ai)
-	bat.Control = actor.NewControl(bat)
-
-	var view *bump.Rect
-	if props.View != nil {
-		viewRect := bump.NewRect(props.View.X, props.View.Y, props.View.Width, props.View.Height)
-		view = &viewRect
-	}
-	bat.ai.SetAct(func() { bat.aiScript(view) })
+func newBatHooks(e *Enemy, _ *core.Properties) *EnemyHooks {
+	awake := false
 
-	return bat
-}
-
-func (r *Bat) Comps() (anim *anim.Comp, body *body.Comp, hitbox *hitbox.Comp, stats *stats.Comp, ai *ai.Comp) {
-	return r.anim, r.body, r.hitbox, r.stats, r.ai
-}
-
-func (r *Bat) Init() {
-	r.body.Weight = 0
-	r.Control.Init()
-}
+	return &EnemyHooks{
+		Actions: map[string]func(def ActionDefinition) *ai.Action{
+			"stalk": func(def ActionDefinition) *ai.Action { return batStalkAction(e, def.Range) },
+			"swoop": func(def ActionDefinition) *ai.Action {
+				_, _, damage := e.actionParams(def)
 
-func (r *Bat) Update(dt float64) {
-	if !r.awake {
-		return
-	}
-	if r.SimpleUpdate(dt); r.awake && r.anim.State == vars.IdleTag {
-		r.anim.SetState(vars.WalkTag)
-	}
-}
-
-//nolint:mnd
-func (r *Bat) aiScript(view *bump.Rect) {
-	if view == nil {
-		x, y, w, h := r.Rect()
-		view = &bump.Rect{X: x - w, Y: y + h, W: w * 3, H: batHeightView}
+				return batSwoopAction(e, def.Tag, damage)
+			},
+		},
+		Init: func() {
+			e.body.Weight = 0
+			if e.view == nil {
+				x, y, w, h := e.Rect()
+				e.view = &bump.Rect{X: x - w, Y: y + h, W: w * 3, H: batHeightView}
+			}
+		},
+		Update: func(dt float64) {
+			if awake = awake || e.ai.Target != nil; !awake {
+				return
+			}
+			if e.SimpleUpdate(dt); e.anim.State == vars.IdleTag {
+				e.anim.SetState(vars.WalkTag)
+			}
+		},
 	}
-	idle := actor.IdleAction(r.Control, view)
-	idle.Exit = func() { r.awake = true }
-	r.ai.Add(0, idle)
-	ai.Choices{
-		{0.3, func() { r.ai.Add(3, r.Stalk(20)) }},
-		{0.3, func() { r.ai.Add(5, r.Stalk(25)) }},
-		{0.3, func() { r.ai.Add(4, r.Stalk(28)) }},
-	}.Play()
-	r.ai.Add(5, r.Attack())
 }
 
-func (r *Bat) Attack() *ai.Action {
+func batSwoopAction(e *Enemy, tag string, damage float64) *ai.Action {
 	// TODO: If the bat hits or reaches it's target, it end the attack
-	action := actor.AttackAction(r.Control, "Attack", batDamage)
+	action := actor.AttackAction(e.Control, tag, damage)
 	originalEntry := action.Entry
 	originalNext := action.Next
 	action.Entry = func() {
 		originalEntry()
-		r.anim.OnFrame(1, func() { r.body.Vx, r.body.Vy = r.targetAngleComps() })
+		e.anim.OnFrame(1, func() { e.body.Vx, e.body.Vy = batTargetAngleComps(e) })
 	}
 	action.Next = func(dt float64) bool {
-		if r.ai.Target == nil {
+		if e.ai.Target == nil {
 			return true
 		}
 
-		compX, compY := r.targetAngleComps()
-		r.body.Vx += compX * dt
-		r.body.Vy += compY * dt
+		compX, compY := batTargetAngleComps(e)
+		e.body.Vx += compX * dt
+		e.body.Vy += compY * dt
 
-		tx, _, tw, _ := r.ai.Target.Rect()
-		r.anim.FlipX = tx+tw/2 > r.X+r.W/2
+		tx, _, tw, _ := e.ai.Target.Rect()
+		e.anim.FlipX = tx+tw/2 > e.X+e.W/2
 
 		return originalNext(dt)
 	}
@@ -125,29 +73,29 @@ func (r *Bat) Attack() *ai.Action {
 	return action
 }
 
-func (r *Bat) Stalk(rangeAdjustment float64) *ai.Action {
+func batStalkAction(e *Enemy, rangeAdjustment float64) *ai.Action {
 	return &ai.Action{
 		Name:  "Stalk",
-		Entry: func() { r.body.MaxX, r.body.MaxY = batMaxSpeed, batMaxSpeed },
+		Entry: func() { e.body.MaxX, e.body.MaxY = e.maxSpeed, e.maxSpeed },
 		Next: func(dt float64) bool {
-			if r.ai.Target == nil {
+			if e.ai.Target == nil {
 				return true
 			}
-			if r.PausingState() {
+			if e.PausingState() {
 				return false
 			}
 
-			compX, compY := r.targetAngleComps()
-			backUp := r.ai.InTargetRange(0, rangeAdjustment)
+			compX, compY := batTargetAngleComps(e)
+			backUp := e.ai.InTargetRange(0, rangeAdjustment)
 			if backUp {
-				r.body.Vx -= compX * dt
-				r.body.Vy -= (batSpeed / 4) * dt
+				e.body.Vx -= compX * dt
+				e.body.Vy -= (e.speed / 4) * dt
 			} else {
-				r.body.Vx += compX * dt
-				if _, ty, _, _ := r.ai.Target.Rect(); r.Y+r.H > ty {
-					r.body.Vy -= batSpeed * 2 * dt
+				e.body.Vx += compX * dt
+				if _, ty, _, _ := e.ai.Target.Rect(); e.Y+e.H > ty {
+					e.body.Vy -= e.speed * 2 * dt
 				} else {
-					r.body.Vy += compY * dt
+					e.body.Vy += compY * dt
 				}
 			}
 
@@ -156,12 +104,12 @@ func (r *Bat) Stalk(rangeAdjustment float64) *ai.Action {
 	}
 }
 
-func (r *Bat) targetAngleComps() (float64, float64) {
-	if r.ai.Target == nil {
+func batTargetAngleComps(e *Enemy) (float64, float64) {
+	if e.ai.Target == nil {
 		return 0, 0
 	}
-	tx, ty, tw, _ := r.ai.Target.Rect()
-	angle := math.Atan2((ty)-(r.Y+r.H), (tx+tw/2)-(r.X+r.W/2))
+	tx, ty, tw, _ := e.ai.Target.Rect()
+	angle := math.Atan2((ty)-(e.Y+e.H), (tx+tw/2)-(e.X+e.W/2))
 
-	return batSpeed * math.Cos(angle), batSpeed * math.Sin(angle)
+	return e.speed * math.Cos(angle), e.speed * math.Sin(angle)
 }
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"encoding/json"
+	"game/comps/faction"
+	"game/comps/render"
+	"game/core"
+	"game/ext"
+	"game/libs/bump"
+	"game/vars"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	bloodstainW, bloodstainH = 7, 2
+	bloodstainPulseSpeed     = 3
+	bloodstainMinAlpha       = 0.5
+)
+
+var bloodstainImage = newBloodstainImage()
+
+// Bloodstain holds the Exp the player dropped on death, given back when touched.
+type Bloodstain struct {
+	*core.BaseEntity
+	render *render.Comp
+	Exp    int
+	taken  bool
+	timer  float64
+}
+
+// NewBloodstain places the stain with its bottom at floorY.
+func NewBloodstain(x, floorY float64, exp int) *Bloodstain {
+	stain := &Bloodstain{
+		BaseEntity: &core.BaseEntity{X: x, Y: floorY - bloodstainH, W: bloodstainW, H: bloodstainH},
+		render:     &render.Comp{Image: bloodstainImage, Layer: -1},
+		Exp:        exp,
+	}
+	stain.Add(stain.render)
+
+	return stain
+}
+
+func (b *Bloodstain) Init() {}
+
+func (b *Bloodstain) Update(dt float64) {
+	b.timer += dt
+	alpha := bloodstainMinAlpha + (1-bloodstainMinAlpha)*(1+math.Sin(b.timer*bloodstainPulseSpeed))/2
+	b.render.ColorScale = color.Alpha{A: uint8(alpha * math.MaxUint8)}
+	if b.taken {
+		return
+	}
+	for _, e := range ext.QueryItems[core.Entity](b, bump.NewRect(b.Rect()), "body") {
+		if faction.Is(e, faction.Player) {
+			b.Take()
+
+			break
+		}
+	}
+}
+
+type bloodstainState struct {
+	X   float64 `json:"x"`
+	Y   float64 `json:"y"`
+	Exp int     `json:"exp"`
+}
+
+func (b *Bloodstain) SaveState() any {
+	if b.taken || b.Exp <= 0 {
+		return nil
+	}
+
+	return bloodstainState{X: b.X, Y: b.Y, Exp: b.Exp}
+}
+
+func (b *Bloodstain) LoadState(data json.RawMessage) error {
+	var state bloodstainState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	b.X, b.Y, b.Exp = state.X, state.Y, state.Exp
+
+	return nil
+}
+
+func (b *Bloodstain) Taken() bool { return b.taken }
+
+func (b *Bloodstain) Take() {
+	b.taken = true
+	EmitFlakes(b, b.Exp)
+	vars.World.Remove(b)
+}
+
+func newBloodstainImage() *ebiten.Image {
+	img := image.NewRGBA(image.Rect(0, 0, bloodstainW, bloodstainH))
+	dark, light := color.RGBA{89, 13, 23, 255}, color.RGBA{172, 50, 50, 255}
+	for x := range bloodstainW {
+		img.Set(x, 1, dark)
+		if x > 0 && x < bloodstainW-1 {
+			img.Set(x, 0, dark)
+		}
+	}
+	img.Set(bloodstainW/2, 0, light)
+	img.Set(bloodstainW/2-1, 1, light)
+
+	return ebiten.NewImageFromImage(img)
+}
@@ -1,7 +1,9 @@
 package entity
 
 import (
+	"encoding/json"
 	"game/assets"
+	"game/comps/faction"
 	"game/comps/hitbox"
 	"game/comps/render"
 	"game/core"
@@ -9,7 +11,6 @@ import (
 	"game/vars"
 	"image"
 	"strconv"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -17,7 +18,7 @@ import (
 
 const (
 	chestW, chestH       = 14, 9
-	chestOpenRewardDelay = 500 * time.Millisecond
+	chestOpenRewardDelay = 0.5
 )
 
 var (
@@ -38,6 +39,7 @@ type Chest struct {
 	hitbox *hitbox.Comp
 	reward int
 	open   bool
+	drop   *itemDrop
 }
 
 func NewChest(x, y, _, _ float64, props *core.Properties) *Chest {
@@ -57,6 +59,7 @@ func NewChest(x, y, _, _ float64, props *core.Properties) *Chest {
 		reward:     reward,
 		open:       props.Custom["open"] == "true",
 	}
+	chest.drop = newItemDrop(chest, props.Custom)
 	chest.Add(chest.render, chest.hitbox)
 
 	return chest
@@ -74,6 +77,9 @@ func (c *Chest) Update(_ float64) {}
 
 func (c *Chest) Opened() bool { return c.open }
 
+func (c *Chest) SaveState() any                       { return saveOpenState(c.open, c.drop) }
+func (c *Chest) LoadState(data json.RawMessage) error { return loadOpenState(data, c.Open, c.drop) }
+
 func (c *Chest) Open() {
 	c.open = true
 	c.hitbox.Remove()
@@ -83,17 +89,16 @@ func (c *Chest) Open() {
 
 func (c *Chest) OpenWithReward() {
 	c.Open()
-	time.AfterFunc(chestOpenRewardDelay, func() {
+	vars.World.Scheduler.After(c, chestOpenRewardDelay, func() {
 		c.render.Image = chestOpenImage
 		c.render.Y = 0
-		for range c.reward {
-			vars.World.Add(NewFlake(c))
-		}
+		EmitFlakes(c, c.reward)
+		c.drop.Drop()
 	})
 }
 
 func (c *Chest) chestHurt(other core.Entity, _ *bump.Collision, _ float64, _ hitbox.ContactType) {
-	if c.open || !core.GetFlag(other, vars.PlayerTeamFlag) {
+	if c.open || !faction.Is(other, faction.Player) {
 		return
 	}
 	c.OpenWithReward()
@@ -1,14 +1,16 @@
 package entity
 
 import (
+	"encoding/json"
 	"game/assets"
 	"game/comps/body"
+	"game/comps/faction"
 	"game/comps/hitbox"
+	"game/comps/inventory"
 	"game/comps/render"
 	"game/core"
 	"game/ext"
 	"game/libs/bump"
-	"game/vars"
 	"image"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -26,6 +28,7 @@ type Door struct {
 	hitbox         *hitbox.Comp
 	open           bool
 	opensFromRight bool
+	key            string
 }
 
 func NewDoor(x, y, _, h float64, props *core.Properties) *Door {
@@ -40,6 +43,7 @@ func NewDoor(x, y, _, h float64, props *core.Properties) *Door {
 		body:           &body.Comp{NoUpdate: true, Tags: []bump.Tag{"solid"}},
 		hitbox:         &hitbox.Comp{},
 		opensFromRight: props.FlipX,
+		key:            props.Custom["key"],
 		open:           props.Custom["open"] == "true",
 	}
 	door.Add(door.render, door.body, door.hitbox)
@@ -61,6 +65,9 @@ func (d *Door) Update(_ float64) {}
 
 func (d *Door) Opened() bool { return d.open }
 
+func (d *Door) SaveState() any                       { return saveOpenState(d.open, nil) }
+func (d *Door) LoadState(data json.RawMessage) error { return loadOpenState(data, d.Open, nil) }
+
 func (d *Door) Open() {
 	if d.open {
 		return
@@ -111,7 +118,10 @@ func (d *Door) setImage() {
 }
 
 func (d *Door) doorHurt(other core.Entity, _ *bump.Collision, _ float64, _ hitbox.ContactType) {
-	if d.open || !core.GetFlag(other, vars.PlayerTeamFlag) {
+	if d.open || !faction.Is(other, faction.Player) {
+		return
+	}
+	if otherInventory := core.Get[*inventory.Comp](other); d.key != "" && (otherInventory == nil || !otherInventory.Has(d.key)) {
 		return
 	}
 	ox, _ := other.Position()
@@ -0,0 +1,560 @@
+package entity
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"game/assets"
+	"game/comps/ai"
+	"game/comps/anim"
+	"game/comps/body"
+	"game/comps/boss"
+	"game/comps/faction"
+	"game/comps/gated"
+	"game/comps/hitbox"
+	"game/comps/stats"
+	"game/core"
+	"game/entity/actor"
+	"game/libs/bump"
+	"game/vars"
+	"image"
+	"image/draw"
+	"image/png"
+	"io/fs"
+	"log"
+	"path"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// factionsFile holds the faction relations among the enemy definitions, see faction.Matrix.
+const factionsFile = "factions.json"
+
+var (
+	enemyEffects = map[string]*stats.Effect{
+		"bleed":   stats.Bleed,
+		"poison":  stats.Poison,
+		"cripple": stats.Cripple,
+		"dread":   stats.Dread,
+	}
+	enemyHooks = map[string]registeredHooks{}
+	// recolored keeps the recolored anim images by anim and swaps, decoded once instead of on every spawn.
+	recolored = map[string]*ebiten.Image{}
+)
+
+// EnemyDefinition describes an enemy built entirely from data, see the enemies folder.
+type EnemyDefinition struct {
+	Name       string             `json:"name"`
+	Anim       string             `json:"anim"`
+	W          float64            `json:"width"`
+	H          float64            `json:"height"`
+	OffsetX    float64            `json:"offset_x"`
+	OffsetY    float64            `json:"offset_y"`
+	OffsetFlip float64            `json:"offset_flip"`
+	Speed      float64            `json:"speed"`
+	MaxSpeed   float64            `json:"max_speed"`
+	Weight     float64            `json:"weight"`
+	Health     float64            `json:"health"`
+	Stamina    float64            `json:"stamina"`
+	Poise      float64            `json:"poise"`
+	Damage     float64            `json:"damage"`
+	Exp        int                `json:"exp"`
+	Effects    []string           `json:"effects"`
+	Faction    faction.Faction    `json:"faction"`
+	Recolor    []ColorSwap        `json:"recolor"`
+	Hooks      string             `json:"hooks"`
+	AI         []ChoiceDefinition `json:"ai"`
+	Tree       *NodeDefinition    `json:"tree"` // Drives the enemy in place of the ai when set.
+	Boss       *BossDefinition    `json:"boss"`
+}
+
+// ColorSwap replaces a color of the anim image by another, like a palette swap.
+type ColorSwap struct {
+	From [3]uint8 `json:"from"`
+	To   [3]uint8 `json:"to"`
+}
+
+// ChoiceDefinition is a weighted ai.Choice, queueing its actions and then playing one of its nested choices.
+type ChoiceDefinition struct {
+	Weight  float64            `json:"weight"`
+	Actions []ActionDefinition `json:"actions"`
+	Then    []ChoiceDefinition `json:"then"`
+}
+
+// NodeDefinition is an ai.Tree node: sequence, selector, parallel, random, invert, repeat or cooldown over its children,
+// or do running its action until the action timeout. Weight is the one of the node under a random one.
+type NodeDefinition struct {
+	Node     string            `json:"node"`
+	Children []NodeDefinition  `json:"children"`
+	Action   *ActionDefinition `json:"action"`
+	Seconds  float64           `json:"seconds"` // Of the cooldown.
+	Weight   float64           `json:"weight"`
+}
+
+// BossDefinition makes the enemy a boss locking the gates of its gate properties. It plays its ai to close in, then
+// one of the choices of its current phase.
+type BossDefinition struct {
+	Name   string            `json:"name"`
+	Phases []PhaseDefinition `json:"phases"`
+}
+
+// PhaseDefinition is a boss.Phase, starting at Threshold of the max health. Speed, MaxSpeed and PlaySpeed change the
+// enemy ones when set.
+type PhaseDefinition struct {
+	Threshold float64            `json:"threshold"`
+	Speed     float64            `json:"speed"`
+	MaxSpeed  float64            `json:"max_speed"`
+	PlaySpeed float64            `json:"play_speed"`
+	AI        []ChoiceDefinition `json:"ai"`
+}
+
+// ActionDefinition is one of the actor actions: idle, wait, approach, follow, backup, attack, jump_attack, shield or
+// shield_backup, or one added by the enemy hooks. Speed and MaxSpeed default to the enemy ones, Jump to its speed.
+type ActionDefinition struct {
+	Action   string   `json:"action"`
+	Timeout  float64  `json:"timeout"`
+	Tag      string   `json:"tag"`
+	Damage   float64  `json:"damage"`
+	Range    float64  `json:"range"`
+	Speed    *float64 `json:"speed"`
+	MaxSpeed *float64 `json:"max_speed"`
+	Jump     *float64 `json:"jump"`
+}
+
+// EnemyHooks is the Go side of an enemy, for what its definition can't express yet like its own actions. Every field
+// is optional.
+type EnemyHooks struct {
+	Actions map[string]func(def ActionDefinition) *ai.Action // Actions the definition ai can use by name.
+	Init    func()                                           // Runs once the comps are initialized.
+	Update  func(dt float64)                                 // Replaces the default update.
+	// Play queues the ai after the idle action, returning false to fall back to the definition one.
+	Play func() bool
+}
+
+type registeredHooks struct {
+	newHooks func(e *Enemy, props *core.Properties) *EnemyHooks
+	actions  []string
+}
+
+type Enemy struct {
+	*core.BaseEntity
+	*actor.Control
+	anim   *anim.Comp
+	body   *body.Comp
+	hitbox *hitbox.Comp
+	stats  *stats.Comp
+	ai     *ai.Comp
+	def    *EnemyDefinition
+	boss   *boss.Comp
+	hooks  *EnemyHooks
+	view   *bump.Rect
+	noAI   bool
+
+	speed, maxSpeed float64
+}
+
+// RegisterEnemyHooks names the hooks a definition picks with its hooks field, listing the Actions they add so the
+// definitions using them can be checked on load. newHooks may return nil to leave an enemy as its definition is.
+func RegisterEnemyHooks(name string, newHooks func(e *Enemy, props *core.Properties) *EnemyHooks, actions ...string) {
+	enemyHooks[name] = registeredHooks{newHooks: newHooks, actions: actions}
+}
+
+// LoadEnemies registers every definition in the json files of fsys by its name, replacing the ones already loaded,
+// and sets the faction relations of its factions.json.
+func LoadEnemies(fsys fs.FS) error {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		if file == factionsFile {
+			relations := faction.NewMatrix()
+			if err := decodeStrict(data, relations); err != nil {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+			faction.Relations = relations
+
+			continue
+		}
+		def := &EnemyDefinition{}
+		if err := decodeStrict(data, def); err != nil {
+			return fmt.Errorf("enemy %s: %w", file, err)
+		}
+		if err := def.validate(); err != nil {
+			return fmt.Errorf("enemy %s: %w", file, err)
+		}
+		if def.Name == "" {
+			def.Name = path.Base(file[:len(file)-len(path.Ext(file))])
+		}
+		core.RegisterEntityName(def.Name, func(x, y, w, h float64, props *core.Properties) *Enemy {
+			return NewEnemy(def, x, y, props)
+		})
+	}
+
+	return nil
+}
+
+// decodeStrict decodes data into v, failing on the fields v doesn't have so typos don't go unnoticed.
+func decodeStrict(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(v)
+}
+
+func NewEnemy(def *EnemyDefinition, x, y float64, props *core.Properties) *Enemy {
+	var effects []*stats.Effect
+	for _, name := range def.Effects {
+		effects = append(effects, enemyEffects[name])
+	}
+	enemy := &Enemy{
+		BaseEntity: &core.BaseEntity{X: x, Y: y, W: def.W, H: def.H},
+		anim:       &anim.Comp{FilesName: def.Anim, OX: def.OffsetX, OY: def.OffsetY, OXFlip: def.OffsetFlip, FlipX: props.FlipX},
+		body:       &body.Comp{MaxX: def.MaxSpeed, Weight: def.Weight},
+		hitbox:     &hitbox.Comp{Effects: effects},
+		stats:      &stats.Comp{MaxHealth: def.Health, MaxStamina: def.Stamina, MaxPoise: def.Poise, Exp: def.Exp},
+		ai:         &ai.Comp{},
+		def:        def,
+		hooks:      &EnemyHooks{},
+		speed:      def.Speed,
+		maxSpeed:   cmp.Or(def.MaxSpeed, vars.DefaultMaxX),
+	}
+	enemy.Add(enemy.anim, enemy.body, enemy.hitbox, enemy.stats, enemy.ai, faction.FromProps(props.Custom, cmp.Or(def.Faction, faction.Monsters)))
+	enemy.Control = actor.NewControl(enemy)
+
+	if props.View != nil {
+		viewRect := bump.NewRect(props.View.X, props.View.Y, props.View.Width, props.View.Height)
+		enemy.view = &viewRect
+	}
+	if def.Boss != nil {
+		enemy.boss = &boss.Comp{Name: def.Boss.Name, Phases: enemy.bossPhases(def.Boss.Phases)}
+		enemy.Add(&gated.Comp{Props: props.Custom}, enemy.boss)
+	}
+	if def.Hooks != "" {
+		if hooks := enemyHooks[def.Hooks].newHooks(enemy, props); hooks != nil {
+			enemy.hooks = hooks
+		}
+	}
+	// An ai property left empty turns the AI off too, like "none".
+	aiProp, ok := props.Custom["ai"]
+	enemy.noAI = ok && (aiProp == "" || aiProp == "none")
+	if !enemy.noAI {
+		enemy.ai.SetAct(func() {
+			enemy.ai.Add(0, actor.IdleAction(enemy.Control, enemy.view))
+			switch {
+			case enemy.hooks.Play != nil && enemy.hooks.Play():
+			case enemy.boss != nil:
+				enemy.playBoss()
+			default:
+				enemy.play(def.AI)
+			}
+		})
+	}
+
+	return enemy
+}
+
+func (e *Enemy) Comps() (anim *anim.Comp, body *body.Comp, hitbox *hitbox.Comp, stats *stats.Comp, ai *ai.Comp) {
+	return e.anim, e.body, e.hitbox, e.stats, e.ai
+}
+
+func (e *Enemy) Init() {
+	e.Control.Init()
+	if len(e.def.Recolor) > 0 {
+		recolor(e.anim, e.def.Recolor)
+	}
+	// The actor actions read the body defaults, so the tree is built once the comps are initialized.
+	if e.def.Tree != nil && !e.noAI {
+		e.ai.SetTree(ai.NewTree(e.node(*e.def.Tree)))
+	}
+	if e.hooks.Init != nil {
+		e.hooks.Init()
+	}
+}
+
+func (e *Enemy) Update(dt float64) {
+	if e.hooks.Update != nil {
+		e.hooks.Update(dt)
+
+		return
+	}
+	e.SimpleUpdate(dt)
+}
+
+func (e *Enemy) play(choices []ChoiceDefinition) { e.choices(choices).Play() }
+
+func (e *Enemy) choices(choices []ChoiceDefinition) ai.Choices {
+	aiChoices := make(ai.Choices, 0, len(choices))
+	for _, choice := range choices {
+		aiChoices = append(aiChoices, ai.Choice{Weight: choice.Weight, Act: func() {
+			for _, action := range choice.Actions {
+				e.ai.Add(action.Timeout, e.action(action))
+			}
+			e.play(choice.Then)
+		}})
+	}
+
+	return aiChoices
+}
+
+// playBoss starts the fight, closing in with the definition ai before playing a choice of the current phase.
+func (e *Enemy) playBoss() {
+	e.ai.Add(1, actor.EntryAction(e.boss.Start))
+	e.play(e.def.AI)
+	e.ai.Add(0, actor.EntryAction(e.boss.Play))
+}
+
+func (e *Enemy) bossPhases(defs []PhaseDefinition) []boss.Phase {
+	phases := make([]boss.Phase, 0, len(defs))
+	for _, def := range defs {
+		phases = append(phases, boss.Phase{
+			Threshold: def.Threshold,
+			Choices:   e.choices(def.AI),
+			Enter: func() {
+				e.speed = cmp.Or(def.Speed, e.speed)
+				if def.MaxSpeed != 0 {
+					e.maxSpeed, e.body.MaxX = def.MaxSpeed, def.MaxSpeed
+				}
+				if def.PlaySpeed != 0 {
+					e.anim.Data.PlaySpeed = float32(def.PlaySpeed)
+				}
+			},
+		})
+	}
+
+	return phases
+}
+
+func (e *Enemy) node(def NodeDefinition) ai.Node {
+	children := make([]ai.Node, 0, len(def.Children))
+	for _, child := range def.Children {
+		children = append(children, e.node(child))
+	}
+	switch def.Node {
+	case "sequence":
+		return ai.Sequence(children...)
+	case "selector":
+		return ai.Selector(children...)
+	case "parallel":
+		return ai.Parallel(children...)
+	case "random":
+		weighted := make([]ai.Weighted, 0, len(children))
+		for i, child := range children {
+			weighted = append(weighted, ai.Weighted{Weight: def.Children[i].Weight, Node: child})
+		}
+
+		return ai.Random(weighted...)
+	case "invert":
+		return ai.Invert(children[0])
+	case "repeat":
+		return ai.Repeat(children[0])
+	case "cooldown":
+		return ai.Cooldown(def.Seconds, children[0])
+	}
+
+	return ai.Do(def.Action.Timeout, e.action(*def.Action))
+}
+
+// actionParams are the speed, max speed and damage of an action, defaulting to the enemy ones.
+func (e *Enemy) actionParams(def ActionDefinition) (speed, maxSpeed, damage float64) {
+	speed, maxSpeed = e.speed, e.maxSpeed
+	if def.Speed != nil {
+		speed = *def.Speed
+	}
+	if def.MaxSpeed != nil {
+		maxSpeed = *def.MaxSpeed
+	}
+
+	return speed, maxSpeed, cmp.Or(def.Damage, e.def.Damage)
+}
+
+func (e *Enemy) action(def ActionDefinition) *ai.Action {
+	speed, maxSpeed, damage := e.actionParams(def)
+	if hook := e.hooks.Actions[def.Action]; hook != nil {
+		return hook(def)
+	}
+
+	switch def.Action {
+	case "idle":
+		return actor.IdleAction(e.Control, e.view)
+	case "approach":
+		return actor.ApproachAction(e.Control, speed, maxSpeed, def.Range)
+	case "follow":
+		return actor.PathFollowAction(e.Control, speed, maxSpeed, def.Range)
+	case "backup":
+		return actor.BackUpAction(e.Control, speed, maxSpeed)
+	case "attack":
+		return actor.AttackAction(e.Control, def.Tag, damage)
+	case "jump_attack":
+		jump := speed
+		if def.Jump != nil {
+			jump = *def.Jump
+		}
+
+		return e.jumpAttackAction(def.Tag, speed, maxSpeed, jump, damage)
+	case "shield":
+		return actor.ShieldAction(e.Control)
+	case "shield_backup":
+		return actor.ShieldBackUpAction(e.Control, speed, maxSpeed)
+	}
+
+	return actor.WaitAction()
+}
+
+// jumpAttackAction leaps at the target, attacking with the tag animation as it takes off.
+func (e *Enemy) jumpAttackAction(tag string, speed, maxSpeed, jump, damage float64) *ai.Action {
+	var push float64
+
+	return &ai.Action{
+		Name: "JumpAttack",
+		Entry: func() {
+			push = speed
+			if e.PausingState() {
+				return
+			}
+			e.body.MaxX = maxSpeed * 2
+			vars.World.Scheduler.After(e, 0, func() { e.Attack(tag, damage, 0, 10, 10) })
+			e.body.Vy = -jump
+			e.body.Ground = false
+			if e.anim.FlipX {
+				e.body.Vx += maxSpeed * 2
+			} else {
+				e.body.Vx -= maxSpeed * 2
+				push *= -1
+			}
+		},
+		Next: func(dt float64) bool {
+			if !e.PausingState() {
+				e.body.Vx += push * dt
+			}
+
+			return e.body.Ground && e.anim.State != tag
+		},
+		Exit: func() { e.body.MaxX = maxSpeed },
+	}
+}
+
+func (def *EnemyDefinition) validate() error {
+	if def.Anim == "" || def.W <= 0 || def.H <= 0 {
+		return fmt.Errorf("anim, width and height are required")
+	}
+	for _, name := range def.Effects {
+		if enemyEffects[name] == nil {
+			return fmt.Errorf("unknown effect %q", name)
+		}
+	}
+	hooks, ok := enemyHooks[def.Hooks]
+	if def.Hooks != "" && !ok {
+		return fmt.Errorf("unknown hooks %q", def.Hooks)
+	}
+	if def.Tree != nil {
+		if err := validateNode(*def.Tree, hooks.actions); err != nil {
+			return err
+		}
+	}
+	if def.Boss != nil {
+		for _, phase := range def.Boss.Phases {
+			if err := validateChoices(phase.AI, hooks.actions); err != nil {
+				return err
+			}
+		}
+	}
+
+	return validateChoices(def.AI, hooks.actions)
+}
+
+func validateChoices(choices []ChoiceDefinition, hookActions []string) error {
+	for _, choice := range choices {
+		for _, action := range choice.Actions {
+			if err := validateAction(action, hookActions); err != nil {
+				return err
+			}
+		}
+		if err := validateChoices(choice.Then, hookActions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateNode(node NodeDefinition, hookActions []string) error {
+	switch node.Node {
+	case "sequence", "selector", "parallel", "random":
+	case "invert", "repeat", "cooldown":
+		if len(node.Children) != 1 {
+			return fmt.Errorf("%s node with %d children, want 1", node.Node, len(node.Children))
+		}
+	case "do":
+		if node.Action == nil {
+			return fmt.Errorf("do node without an action")
+		}
+
+		return validateAction(*node.Action, hookActions)
+	default:
+		return fmt.Errorf("unknown node %q", node.Node)
+	}
+	for _, child := range node.Children {
+		if err := validateNode(child, hookActions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateAction(action ActionDefinition, hookActions []string) error {
+	switch action.Action {
+	case "idle", "wait", "approach", "follow", "backup", "shield", "shield_backup":
+	case "attack", "jump_attack":
+		if action.Tag == "" {
+			return fmt.Errorf("%s action without an anim tag", action.Action)
+		}
+	default:
+		if !slices.Contains(hookActions, action.Action) {
+			return fmt.Errorf("unknown action %q", action.Action)
+		}
+	}
+
+	return nil
+}
+
+// recolor swaps the colors of the anim image, decoding its file since ReadPixels is not available when running
+// headless.
+func recolor(animComp *anim.Comp, swaps []ColorSwap) {
+	key := fmt.Sprint(animComp.FilesName, swaps)
+	if img, ok := recolored[key]; ok {
+		animComp.Image = img
+
+		return
+	}
+	file, err := assets.FS.Open(animComp.FilesName + ".png")
+	if err != nil {
+		log.Panic(err)
+	}
+	defer file.Close()
+
+	src, err := png.Decode(file)
+	if err != nil {
+		log.Panic(err)
+	}
+	img := image.NewRGBA(src.Bounds())
+	draw.Draw(img, img.Bounds(), src, image.Point{}, draw.Src)
+	for i := 0; i+3 < len(img.Pix); i += 4 {
+		for _, swap := range swaps {
+			if [3]uint8(img.Pix[i:i+3]) == swap.From {
+				copy(img.Pix[i:i+3], swap.To[:])
+
+				break
+			}
+		}
+	}
+	recolored[key] = ebiten.NewImageFromImage(img)
+	animComp.Image = recolored[key]
+}
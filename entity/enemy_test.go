@@ -0,0 +1,119 @@
+package entity_test
+
+import (
+	"game/comps/ai"
+	"game/comps/anim"
+	"game/comps/body"
+	"game/comps/boss"
+	"game/comps/faction"
+	"game/comps/stats"
+	"game/core"
+	"game/core/coretest"
+	"game/enemies"
+	"game/entity"
+	"game/vars"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadEnemies(t *testing.T) {
+	if err := entity.LoadEnemies(enemies.FS); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Knight", "Ghoul", "Skeleman", "Crawler", "Rat", "Bat", "Ent"} {
+		if core.EntityByName(name) == nil {
+			t.Errorf("enemy %s not registered", name)
+		}
+	}
+
+	for def, want := range map[string]bool{
+		`{"anim": "rat", "width": 1, "height": 1, "hooks": "rat", "ai": [{"actions": [{"action": "pounce"}]}]}`: true,
+		`{"anim": "rat", "width": 1, "height": 1, "ai": [{"actions": [{"action": "pounce"}]}]}`:                 false,
+		`{"anim": "rat", "width": 1, "height": 1, "hooks": "dragon"}`:                                           false,
+		`{"anim": "rat", "width": 1, "height": 1, "heatlh": 10}`:                                                false,
+		`{"anim": "rat", "width": 1, "height": 1, "tree": {"node": "cooldown", "seconds": 1}}`:                  false,
+		`{"anim": "rat", "width": 1, "height": 1, "tree": {"node": "do", "action": {"action": "pounce"}}}`:      false,
+	} {
+		fsys := fstest.MapFS{"test.json": {Data: []byte(def)}}
+		if err := entity.LoadEnemies(fsys); (err == nil) != want {
+			t.Errorf("%s loaded with error %v, want it loaded %v", def, err, want)
+		}
+	}
+	fsys := fstest.MapFS{"factions.json": {Data: []byte(`[{"factions": ["player", "monsters"], "relation": "foes"}]`)}}
+	if err := entity.LoadEnemies(fsys); err == nil {
+		t.Error("factions with an unknown relation loaded")
+	}
+	// Failing halfway through, none of the relations are set.
+	fsys = fstest.MapFS{"factions.json": {Data: []byte(
+		`[{"factions": ["player", "monsters"], "relation": "allies"}, {"factions": ["player"], "relation": "enemies"}]`,
+	)}}
+	if err := entity.LoadEnemies(fsys); err == nil {
+		t.Error("factions with a relation missing a faction loaded")
+	}
+	if relation := faction.Relations.Get("player", "monsters"); relation != faction.Enemies {
+		t.Errorf("player and monsters relation %v after factions failed to load, want them enemies", relation)
+	}
+}
+
+func TestEntNoticesPlayer(t *testing.T) {
+	if err := entity.LoadEnemies(enemies.FS); err != nil {
+		t.Fatal(err)
+	}
+	coretest.NewWorld()
+	ent := core.EntityByName("Ent")(100, 0, 12, 16, &core.Properties{})
+	player := entity.NewPlayer(80, 0, entity.PlayerLevels{})
+	vars.World.Add(ent)
+	vars.World.Add(player)
+	vars.World.Camera.Follow(ent)
+
+	for range 10 {
+		vars.World.Update(1.0 / 60)
+	}
+	if target := core.Get[*ai.Comp](ent).Target; target != player {
+		t.Errorf("ent target = %v, want the player in front of it", target)
+	}
+}
+
+func TestKnightPhases(t *testing.T) {
+	if err := entity.LoadEnemies(enemies.FS); err != nil {
+		t.Fatal(err)
+	}
+	coretest.NewWorld()
+	knight := vars.World.Add(core.EntityByName("Knight")(100, 0, 8, 11, &core.Properties{}))
+	vars.World.Update(0)
+	bossComp, knightStats := core.Get[*boss.Comp](knight), core.Get[*stats.Comp](knight)
+	if bossComp == nil || bossComp.Name != "Red Knight" {
+		t.Fatalf("knight boss = %+v, want the Red Knight", bossComp)
+	}
+
+	bossComp.Start()
+	knightStats.Health = knightStats.MaxHealth * 0.7
+	vars.World.Update(1.0 / 60)
+	if bossComp.Phase() != 1 {
+		t.Fatalf("knight on phase %d at 70%% health, want the second", bossComp.Phase())
+	}
+	if maxX := core.Get[*body.Comp](knight).MaxX; maxX != 60 {
+		t.Errorf("knight max speed %v in the second phase, want 60", maxX)
+	}
+	if playSpeed := core.Get[*anim.Comp](knight).Data.PlaySpeed; playSpeed != 1.5 {
+		t.Errorf("knight play speed %v in the second phase, want 1.5", playSpeed)
+	}
+}
+
+func TestTreeWithoutAI(t *testing.T) {
+	if err := entity.LoadEnemies(enemies.FS); err != nil {
+		t.Fatal(err)
+	}
+	coretest.NewWorld()
+	skeleman := core.EntityByName("Skeleman")(100, 0, 8, 12, &core.Properties{Custom: map[string]string{"ai": "none"}})
+	vars.World.Add(skeleman)
+	vars.World.Add(entity.NewPlayer(80, 0, entity.PlayerLevels{}))
+	vars.World.Camera.Follow(skeleman)
+
+	for range 60 {
+		vars.World.Update(1.0 / 60)
+	}
+	if x, _ := skeleman.Position(); x != 100 {
+		t.Errorf("skeleman with no ai moved from x 100 to %v", x)
+	}
+}
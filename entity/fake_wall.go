@@ -1,22 +1,21 @@
 package entity
 
 import (
+	"encoding/json"
 	"game/comps/body"
+	"game/comps/faction"
 	"game/comps/hitbox"
 	"game/comps/render"
 	"game/core"
 	"game/ext"
 	"game/libs/bump"
+	"game/utils"
 	"game/vars"
 	"log"
-	"math/rand/v2"
-	"sync"
-	"time"
+	"slices"
 )
 
-const fakeWallOpenNeighborDelay = 300 * time.Millisecond
-
-var mutex sync.Mutex
+const fakeWallOpenNeighborDelay = 0.3
 
 type FakeWall struct {
 	*core.BaseEntity
@@ -26,7 +25,6 @@ type FakeWall struct {
 	open                 bool
 }
 
-// TODO: add to opened in savefile (?) this can not be done as is is destroyed when open
 func NewFakeWall(x, y, _, _ float64, _ *core.Properties) *FakeWall {
 	tiles, err := vars.World.Map.TilesFromPosition(x, y, true, vars.World.Space)
 	if err != nil {
@@ -54,27 +52,45 @@ func (fw *FakeWall) Update(_ float64) {}
 
 func (fw *FakeWall) Opened() bool { return fw.open }
 
-func (fw *FakeWall) OpenInChain() {
-	mutex.Lock()
-	defer mutex.Unlock()
+func (fw *FakeWall) SaveState() any                       { return saveOpenState(fw.open, nil) }
+func (fw *FakeWall) LoadState(data json.RawMessage) error { return loadOpenState(data, fw.Open, nil) }
 
+// OpenInChain opens the wall and then the walls around it, a wave of neighbors after the other.
+func (fw *FakeWall) OpenInChain() {
 	if fw.open {
 		return
 	}
-	fw.Open()
-	vars.World.Camera.Shake(0.1, 0.1)
-	for range 5 + rand.IntN(5) {
-		vars.World.Add(NewSmoke(fw))
-	}
-	time.AfterFunc(fakeWallOpenNeighborDelay, func() {
-		horizonal := ext.QueryItems(fw, bump.Rect{X: fw.X - tileSize/2, Y: fw.Y, W: tileSize * 2, H: tileSize}, "fakeWall")
-		vertical := ext.QueryItems(fw, bump.Rect{X: fw.X, Y: fw.Y - tileSize/2, W: tileSize, H: tileSize * 2}, "fakeWall")
-		for _, neighbor := range append(horizonal, vertical...) {
-			neighbor.OpenInChain()
+	// Not owned by the wall, it's removed once open.
+	vars.World.Scheduler.Run(nil, func(yield func(float64) bool) {
+		for wave := []*FakeWall{fw}; len(wave) > 0; {
+			for _, wall := range wave {
+				wall.Open()
+				vars.World.Camera.Shake(0.1, 0.1)
+				EmitSmoke(wall, 5+utils.Rand.IntN(5))
+			}
+			if !yield(fakeWallOpenNeighborDelay) {
+				return
+			}
+			var next []*FakeWall
+			for _, wall := range wave {
+				for _, neighbor := range wall.neighbors() {
+					if !neighbor.open && !slices.Contains(next, neighbor) {
+						next = append(next, neighbor)
+					}
+				}
+			}
+			wave = next
 		}
 	})
 }
 
+func (fw *FakeWall) neighbors() []*FakeWall {
+	horizonal := ext.QueryItems(fw, bump.Rect{X: fw.X - tileSize/2, Y: fw.Y, W: tileSize * 2, H: tileSize}, "fakeWall")
+	vertical := ext.QueryItems(fw, bump.Rect{X: fw.X, Y: fw.Y - tileSize/2, W: tileSize, H: tileSize * 2}, "fakeWall")
+
+	return append(horizonal, vertical...)
+}
+
 func (fw *FakeWall) Open() {
 	if fw.open {
 		return
@@ -84,7 +100,7 @@ func (fw *FakeWall) Open() {
 }
 
 func (fw *FakeWall) hurt(other core.Entity, _ *bump.Collision, _ float64, _ hitbox.ContactType) {
-	if fw.open || !core.GetFlag(other, vars.PlayerTeamFlag) {
+	if fw.open || !faction.Is(other, faction.Player) {
 		return
 	}
 	fw.OpenInChain()
@@ -0,0 +1,27 @@
+package entity_test
+
+import (
+	"game/comps/stats"
+	"game/core"
+	"game/core/coretest"
+	"game/entity"
+	"game/entity/actor"
+	"game/vars"
+	"testing"
+)
+
+func TestFlakesOnFullParticles(t *testing.T) {
+	coretest.NewWorld()
+	vars.Player = entity.NewPlayer(0, 0, entity.PlayerLevels{})
+	vars.World.Add(vars.Player)
+	vars.World.Update(0)
+	for vars.World.Particles.Len() < core.MaxParticles {
+		vars.World.Particles.Emit(actor.Dust, 0, 0)
+	}
+
+	exp := core.Get[*stats.Comp](vars.Player).Exp
+	entity.EmitFlakes(newIdleEnemy(50, 0), 5)
+	if got := core.Get[*stats.Comp](vars.Player).Exp - exp; got != 5 {
+		t.Errorf("player got %d exp from 5 flakes thrown on full particles, want 5", got)
+	}
+}
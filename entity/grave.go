@@ -2,6 +2,7 @@ package entity
 
 import (
 	"game/assets"
+	"game/comps/faction"
 	"game/comps/render"
 	"game/comps/textbox"
 	"game/core"
@@ -49,14 +50,13 @@ func (g *Grave) Init() {}
 func (g *Grave) Update(_ float64) {
 	active := false
 	for _, e := range ext.QueryItems[core.Entity](g, bump.NewRect(g.Rect()), "body") {
-		if core.GetFlag(e, vars.PlayerTeamFlag) {
+		if faction.Is(e, faction.Player) {
 			active = true
 
 			break
 		}
 	}
-	if active && vars.Pad.KeyPressed(utils.KeyUp) {
-		vars.SaveGame = true
-		vars.ResetGame = true
+	if active && vars.Controls.KeyPressed(utils.KeyUp) {
+		core.Publish(vars.World.Events, core.RestAtGrave{Grave: g})
 	}
 }
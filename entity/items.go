@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"game/comps/inventory"
+	"game/comps/stats"
+	"game/core"
+	"image/color"
+)
+
+func init() {
+	inventory.Register(&inventory.Item{
+		ID: "ember", Name: "Ember", Kind: inventory.Consumable,
+		Description: "A warm coal. Restores some health.",
+		Color:       color.RGBA{223, 113, 38, 255},
+		Use:         func(owner core.Entity) { core.Get[*stats.Comp](owner).AddHealth(30) },
+	})
+	inventory.Register(&inventory.Item{
+		ID: "flask_shard", Name: "Flask Shard", Kind: inventory.Consumable,
+		Description: "Refills one heal.",
+		Color:       color.RGBA{99, 155, 255, 255},
+		Use:         func(owner core.Entity) { core.Get[*stats.Comp](owner).AddHeal(1) },
+	})
+	inventory.Register(&inventory.Item{
+		ID: "rusty_key", Name: "Rusty Key", Kind: inventory.Key,
+		Description: "Opens an old locked door.",
+		Color:       color.RGBA{143, 86, 59, 255},
+	})
+	inventory.Register(&inventory.Item{
+		ID: "iron_charm", Name: "Iron Charm", Kind: inventory.Charm,
+		Description: "Heavy on the neck. Raises poise.",
+		Color:       color.RGBA{155, 173, 183, 255},
+		Use: func(owner core.Entity) {
+			ownerStats := core.Get[*stats.Comp](owner)
+			ownerStats.MaxPoise += 5
+			ownerStats.Poise += 5
+		},
+	})
+	inventory.Register(&inventory.Item{
+		ID: "blood_charm", Name: "Blood Charm", Kind: inventory.Charm,
+		Description: "Still beating. Raises max health.",
+		Color:       color.RGBA{172, 50, 50, 255},
+		Use: func(owner core.Entity) {
+			ownerStats := core.Get[*stats.Comp](owner)
+			ownerStats.MaxHealth += 15
+			ownerStats.Health += 15
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"encoding/json"
 	"game/assets"
 	"game/comps/body"
 	"game/comps/hitbox"
@@ -8,10 +9,10 @@ import (
 	"game/core"
 	"game/ext"
 	"game/libs/bump"
+	"game/utils"
 	"game/vars"
 	"log"
 	"math"
-	"math/rand/v2"
 	"strconv"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -24,6 +25,8 @@ type Object struct {
 	hitbox               *hitbox.Comp
 	render, renderNormal *render.Comp
 	reward               int
+	broken               bool
+	drop                 *itemDrop
 }
 
 func init() { core.RegisterEntityName("Object", NewObject) }
@@ -40,6 +43,7 @@ func NewObject(x, y, w, h float64, props *core.Properties) *Object {
 		renderNormal: &render.Comp{Image: normalImage, X: -dx, Y: -dy, Normal: true},
 		reward:       reward,
 	}
+	object.drop = newItemDrop(object, props.Custom)
 	object.Add(object.body, object.hitbox, object.render, object.renderNormal)
 
 	return object
@@ -59,15 +63,24 @@ func (o *Object) Update(_ float64) {
 	}
 }
 
+func (o *Object) SaveState() any                       { return saveOpenState(o.broken, o.drop) }
+func (o *Object) LoadState(data json.RawMessage) error { return loadOpenState(data, o.Break, o.drop) }
+
+// Break removes the object without any debris or reward.
+func (o *Object) Break() {
+	o.broken = true
+	vars.World.Remove(o)
+}
+
 func (o *Object) hurt(other core.Entity, _ *bump.Collision, _ float64, _ hitbox.ContactType) {
-	for range 5 + rand.IntN(5) {
-		vars.World.Add(NewSmoke(o))
+	debris := 5 + utils.Rand.IntN(5)
+	for range debris {
 		vars.World.Add(NewDebris(o))
 	}
-	for range o.reward {
-		vars.World.Add(NewFlake(o))
-	}
-	vars.World.Remove(o)
+	EmitSmoke(o, debris)
+	EmitFlakes(o, o.reward)
+	o.drop.Drop()
+	o.Break()
 }
 
 func constructTileImages(x, y, w, h float64) (*ebiten.Image, *ebiten.Image) {
@@ -107,7 +120,11 @@ func constructTileImages(x, y, w, h float64) (*ebiten.Image, *ebiten.Image) {
 	return image, normalImage
 }
 
-const debrisDuration = 5.0
+const (
+	debrisDuration                   = 5.0
+	debrisSpawnMinX, debrisSpawnMaxX = -100, 100
+	debrisSpawnMinY, debrisSpawnMaxY = -50, -100
+)
 
 var debrisImage, _, _ = ebitenutil.NewImageFromFileSystem(assets.FS, "debris.png")
 
@@ -130,8 +147,8 @@ func NewDebris(from core.Entity) *Debris {
 		body:        &body.Comp{Tags: []bump.Tag{}, QueryTags: []bump.Tag{"map"}},
 		render:      &render.Comp{Image: debrisImage},
 		from:        from,
-		randTargetW: rand.Float64(), randTargetH: rand.Float64(),
-		timer:         debrisDuration * (0.5 + rand.Float64()),
+		randTargetW: utils.Rand.Float64(), randTargetH: utils.Rand.Float64(),
+		timer:         debrisDuration * (0.5 + utils.Rand.Float64()),
 		rotationSpeed: RandSignedFloat() * 4 * math.Pi,
 	}
 	debris.Add(debris.body, debris.render)
@@ -140,8 +157,8 @@ func NewDebris(from core.Entity) *Debris {
 }
 
 func (d *Debris) Init() {
-	vx := flakeSpawnMinX + rand.Float64()*(flakeSpawnMaxX-flakeSpawnMinX)
-	vy := flakeSpawnMinY + rand.Float64()*(flakeSpawnMaxY-flakeSpawnMinY)
+	vx := debrisSpawnMinX + utils.Rand.Float64()*(debrisSpawnMaxX-debrisSpawnMinX)
+	vy := debrisSpawnMinY + utils.Rand.Float64()*(debrisSpawnMaxY-debrisSpawnMinY)
 	d.body.Vx, d.body.Vy = vx, vy
 }
 
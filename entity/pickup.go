@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"game/comps/body"
+	"game/comps/faction"
+	"game/comps/inventory"
+	"game/comps/render"
+	"game/core"
+	"game/ext"
+	"game/libs/bump"
+	"game/vars"
+	"image"
+	"image/color"
+	"log"
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	pickupSize                   = 5
+	pickupSpawnVy                = -80
+	pickupBobSpeed, pickupBobMax = 4, 1
+)
+
+var pickupImage = newPickupImage()
+
+// Pickup is an item laying in the world, added to the player inventory when touched.
+type Pickup struct {
+	*core.BaseEntity
+	body   *body.Comp
+	render *render.Comp
+	item   *inventory.Item
+	count  int
+	timer  float64
+	picked bool
+}
+
+// NewPickup pops an item out of from, it returns nil when the item is not registered.
+func NewPickup(from core.Entity, itemID string, count int) *Pickup {
+	item := inventory.Lookup(itemID)
+	if item == nil {
+		log.Printf("pickup: unknown item %q", itemID)
+
+		return nil
+	}
+	x, y, w, h := from.Rect()
+	pickup := &Pickup{
+		BaseEntity: &core.BaseEntity{X: x + (w-pickupSize)/2, Y: y + (h-pickupSize)/2, W: pickupSize, H: pickupSize},
+		body:       &body.Comp{Tags: []bump.Tag{}, QueryTags: []bump.Tag{"map"}},
+		render:     &render.Comp{Image: pickupImage, ColorScale: item.Color, Layer: 1},
+		item:       item,
+		count:      max(count, 1),
+	}
+	pickup.Add(pickup.body, pickup.render)
+
+	return pickup
+}
+
+func (p *Pickup) Init() { p.body.Vy = pickupSpawnVy }
+
+func (p *Pickup) Update(dt float64) {
+	if p.body.Ground {
+		p.timer += dt
+		p.render.Y = math.Round(math.Sin(p.timer*pickupBobSpeed)*pickupBobMax) - pickupBobMax
+	}
+	for _, e := range ext.QueryItems[core.Entity](p, bump.NewRect(p.Rect()), "body") {
+		if playerInventory := core.Get[*inventory.Comp](e); playerInventory != nil && faction.Is(e, faction.Player) {
+			p.picked = true
+			playerInventory.Add(p.item.ID, p.count)
+			core.Publish(vars.World.Events, core.ItemPicked{Entity: e, Item: p.item.ID, Count: p.count})
+			vars.World.Remove(p)
+
+			return
+		}
+	}
+}
+
+// itemDrop is the pickup chosen by the item and count properties of a Tiled object, dropped once it's opened or
+// broken and kept in its state until it's picked up.
+type itemDrop struct {
+	from    core.Entity
+	itemID  string
+	count   int
+	pickup  *Pickup
+	claimed bool
+}
+
+func newItemDrop(from core.Entity, props map[string]string) *itemDrop {
+	count, _ := strconv.Atoi(props["count"])
+
+	return &itemDrop{from: from, itemID: props["item"], count: count}
+}
+
+// Drop pops the pickup out of the entity, once.
+func (d *itemDrop) Drop() {
+	if d.itemID == "" || d.claimed || d.pickup != nil {
+		return
+	}
+	if d.pickup = NewPickup(d.from, d.itemID, d.count); d.pickup == nil {
+		d.claimed = true
+
+		return
+	}
+	vars.World.Add(d.pickup)
+}
+
+// unclaimed reports whether the item is still to be picked up, dropped already or not.
+func (d *itemDrop) unclaimed() bool {
+	return d != nil && d.itemID != "" && !d.claimed && (d.pickup == nil || !d.pickup.picked)
+}
+
+// load drops the item again when it was left unclaimed in the save.
+func (d *itemDrop) load(unclaimed bool) {
+	if d == nil {
+		return
+	}
+	if unclaimed {
+		d.Drop()
+	} else {
+		d.claimed = true
+	}
+}
+
+func newPickupImage() *ebiten.Image {
+	img := image.NewRGBA(image.Rect(0, 0, pickupSize, pickupSize))
+	center := pickupSize / 2
+	for y := range pickupSize {
+		for x := range pickupSize {
+			if dist := abs(x-center) + abs(y-center); dist < center {
+				img.Set(x, y, color.White)
+			} else if dist == center {
+				img.Set(x, y, color.Gray{Y: 160})
+			}
+		}
+	}
+
+	return ebiten.NewImageFromImage(img)
+}
+
+func abs(n int) int { return max(n, -n) }
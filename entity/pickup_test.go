@@ -0,0 +1,52 @@
+package entity_test
+
+import (
+	"game/core"
+	"game/core/coretest"
+	"game/entity"
+	"game/vars"
+	"testing"
+)
+
+func newChestWorld() *entity.Chest {
+	coretest.NewWorld()
+	chest := entity.NewChest(0, 0, 0, 0, &core.Properties{Custom: map[string]string{"item": "ember", "reward": "0"}})
+	vars.World.AddWithID(chest, 1)
+	vars.World.Update(0)
+
+	return chest
+}
+
+func pickups() int {
+	count := 0
+	for _, e := range vars.World.GetAll() {
+		if _, ok := e.(*entity.Pickup); ok {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestUnclaimedDropKept(t *testing.T) {
+	newChestWorld().OpenWithReward()
+	for range 60 {
+		vars.World.Update(1.0 / 60)
+	}
+	if pickups() != 1 {
+		t.Fatalf("%d pickups dropped by the chest, want 1", pickups())
+	}
+	states, err := vars.World.SaveStates()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chest := newChestWorld()
+	if err := vars.World.LoadStates(states); err != nil {
+		t.Fatal(err)
+	}
+	vars.World.Update(0)
+	if !chest.Opened() || pickups() != 1 {
+		t.Errorf("reloaded chest open %v with %d pickups, want it open with its unclaimed item", chest.Opened(), pickups())
+	}
+}
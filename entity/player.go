@@ -4,17 +4,19 @@ import (
 	"game/comps/ai"
 	"game/comps/anim"
 	"game/comps/body"
+	"game/comps/faction"
 	"game/comps/hitbox"
+	"game/comps/inventory"
 	"game/comps/stats"
 	"game/core"
 	"game/entity/actor"
+	"game/ext"
 	"game/libs/bump"
 	"game/utils"
 	"game/vars"
 	"log"
 	"math"
 	"strings"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
@@ -25,39 +27,64 @@ const (
 
 	playerMaxX, playerSpeed, playerJumpSpeed, playerClimbSpeed = 55, 350, 110, 5
 	playerDamage, playerPoise                                  = 20, 16
+	playerHealth, playerStamina, playerHeal                    = 60, 65, 5
 	jumpingStamina                                             = 30
 
-	keyBufferDuration = 500 * time.Millisecond
+	healthPerLevel, staminaPerLevel, poisePerLevel = 8, 6, 2
+	damagePerLevel, healPerLevel                   = 2, 1
+
+	keyBufferSeconds = 0.5
 )
 
 type Player struct {
 	*core.BaseEntity
 	*actor.Control
-	anim   *anim.Comp
-	body   *body.Comp
-	hitbox *hitbox.Comp
-	stats  *stats.Comp
+	anim      *anim.Comp
+	body      *body.Comp
+	hitbox    *hitbox.Comp
+	stats     *stats.Comp
+	inventory *inventory.Comp
 
 	speed, jumpSpeed            float64
 	reactForce, attackPushForce float64
 	attackLevel                 float64
+	damage                      float64
+	groundX, groundY            float64
+}
+
+// PlayerLevels counts the levels bought for each stat with Exp.
+type PlayerLevels struct {
+	Health  int `json:"health"`
+	Stamina int `json:"stamina"`
+	Poise   int `json:"poise"`
+	Attack  int `json:"attack"`
+	Heal    int `json:"heal"`
 }
 
-func NewPlayer(x, y float64) *Player {
+func (l PlayerLevels) Total() int { return l.Health + l.Stamina + l.Poise + l.Attack + l.Heal }
+
+func NewPlayer(x, y float64, levels PlayerLevels) *Player {
 	p := &Player{
 		BaseEntity: &core.BaseEntity{X: x, Y: y, W: knightWidth, H: knightHeight},
 		anim:       &anim.Comp{FilesName: knightAnimFile, OX: knightOffsetX, OY: knightOffsetY, OXFlip: knightOffsetFlip},
 		body:       &body.Comp{MaxX: playerMaxX},
 		hitbox:     &hitbox.Comp{},
-		stats:      &stats.Comp{Hud: true, NoDebug: true, MaxHealth: 60, MaxStamina: 65, MaxPoise: playerPoise, MaxHeal: 5},
+		stats: &stats.Comp{
+			Hud: true, NoDebug: true,
+			MaxHealth:  playerHealth + float64(levels.Health*healthPerLevel),
+			MaxStamina: playerStamina + float64(levels.Stamina*staminaPerLevel),
+			MaxPoise:   playerPoise + float64(levels.Poise*poisePerLevel),
+			MaxHeal:    playerHeal + levels.Heal*healPerLevel,
+		},
 
 		attackPushForce: vars.DefaultAttackPushForce,
 		reactForce:      vars.DefaultReactForce,
+		inventory:       &inventory.Comp{},
 		speed:           playerSpeed, jumpSpeed: playerJumpSpeed,
+		damage: playerDamage + float64(levels.Attack*damagePerLevel),
 	}
-	p.Add(p.anim, p.body, p.hitbox, p.stats)
+	p.Add(p.anim, p.body, p.hitbox, p.stats, p.inventory, &faction.Comp{Faction: faction.Player})
 	p.Control = actor.NewControl(p)
-	core.SetFlag(p, vars.PlayerTeamFlag, true)
 
 	return p
 }
@@ -66,7 +93,11 @@ func (p *Player) Comps() (anim *anim.Comp, body *body.Comp, hitbox *hitbox.Comp,
 	return p.anim, p.body, p.hitbox, p.stats, nil
 }
 
+// Ground is where the feet of the player last were on safe ground, away from hazards like spikes.
+func (p *Player) Ground() (float64, float64) { return p.groundX, p.groundY }
+
 func (p *Player) Init() {
+	p.updateGround(true)
 	hurtbox, err := p.anim.FrameSlice(vars.HurtboxSliceName)
 	if err != nil {
 		log.Panicf("player: %s", err)
@@ -76,8 +107,6 @@ func (p *Player) Init() {
 		switch contactType {
 		case hitbox.Hit:
 			p.Hurt(other, damage, p.reactForce)
-			vars.World.Camera.Shake(0.5, 1)
-			vars.World.Freeze(0.1)
 		case hitbox.Block, hitbox.ParryBlock:
 			p.Block(other, damage, p.reactForce, contactType)
 		}
@@ -90,13 +119,22 @@ func (p *Player) Update(dt float64) {
 		p.heavyAttackUpdate()
 	}
 	p.SimpleUpdate(dt)
+	p.updateGround(p.body.Ground && p.stats.Health > 0)
 	if p.stats.Health > 0 {
-		if moving := vars.Pad.KeyDown(utils.KeyLeft) || vars.Pad.KeyDown(utils.KeyRight); !moving && p.anim.State == vars.WalkTag {
+		if moving := vars.Controls.KeyDown(utils.KeyLeft) || vars.Controls.KeyDown(utils.KeyRight); !moving && p.anim.State == vars.WalkTag {
 			p.anim.SetState(vars.IdleTag)
 		}
 	}
 }
 
+func (p *Player) updateGround(grounded bool) {
+	rect := bump.NewRect(p.Rect())
+	if !grounded || len(ext.QueryItems[core.Entity](p, rect, hazardTag)) > 0 {
+		return
+	}
+	p.groundX, p.groundY = rect.X, rect.Y+rect.H
+}
+
 func (p *Player) heavyAttackUpdate() {
 	if attacking := strings.HasPrefix(p.anim.State, vars.AttackTag); !attacking {
 		return
@@ -110,39 +148,39 @@ func (p *Player) heavyAttackUpdate() {
 	frame := p.anim.Data.CurrentFrame
 	if inHold := frame >= holdAnim.From && frame <= holdAnim.To; inHold {
 		p.attackLevel = float64(frame-holdAnim.From+startingFrames) / float64(holdAnim.To-holdAnim.From+startingFrames) // 0, 0.5, 1 || 0, 0.33, 0.66, 1
-		if vars.Pad.KeyReleased(utils.KeyAction) {
+		if vars.Controls.KeyReleased(utils.KeyAction) {
 			p.anim.Data.CurrentFrame = holdAnim.To + 2
 			p.anim.Data.FrameCounter = 0
 		}
-	} else if p.anim.Data.CurrentFrame == holdAnim.From-startingFrames && vars.Pad.KeyReleased(utils.KeyAction) {
+	} else if p.anim.Data.CurrentFrame == holdAnim.From-startingFrames && vars.Controls.KeyReleased(utils.KeyAction) {
 		p.anim.Data.CurrentFrame = holdAnim.To + 1
 	}
 }
 
 func (p *Player) input(dt float64) {
-	actionPressed := vars.Pad.KeyPressedBuffered(utils.KeyAction, keyBufferDuration)
-	healPressed := vars.Pad.KeyPressedBuffered(utils.KeyHeal, keyBufferDuration)
-	dashPressed := vars.Pad.KeyPressedBuffered(utils.KeyDash, keyBufferDuration)
+	actionPressed := vars.Controls.KeyPressedBuffered(utils.KeyAction, keyBufferSeconds)
+	healPressed := vars.Controls.KeyPressedBuffered(utils.KeyHeal, keyBufferSeconds)
+	dashPressed := vars.Controls.KeyPressedBuffered(utils.KeyDash, keyBufferSeconds)
 	if p.PausingState() && p.anim.State != vars.ConsumeTag {
 		return
 	}
 	if actionPressed() {
-		p.MultAttack(vars.AttackTag, playerDamage, playerDamage, p.reactForce, p.attackPushForce, &p.attackLevel)
+		p.MultAttack(vars.AttackTag, p.damage, p.damage, p.reactForce, p.attackPushForce, &p.attackLevel)
 	}
 	if healPressed() {
 		p.Heal()
 	}
 	if dashPressed() {
 		speed := p.body.MaxX * 4
-		if (!p.anim.FlipX && !vars.Pad.KeyDown(utils.KeyRight)) || vars.Pad.KeyDown(utils.KeyLeft) {
+		if (!p.anim.FlipX && !vars.Controls.KeyDown(utils.KeyRight)) || vars.Controls.KeyDown(utils.KeyLeft) {
 			speed *= -1
 		}
 		p.body.Vx = speed
 	}
-	if vars.Pad.KeyDown(utils.KeyGuard) {
+	if vars.Controls.KeyDown(utils.KeyGuard) {
 		p.ShieldUp()
 	}
-	if vars.Pad.KeyReleased(utils.KeyGuard) {
+	if vars.Controls.KeyReleased(utils.KeyGuard) {
 		p.ShieldDown()
 	}
 	p.inputClimbing(dt)
@@ -152,13 +190,13 @@ func (p *Player) input(dt float64) {
 	if !p.body.Ground {
 		speed /= 2
 	}
-	if vars.Pad.KeyDown(utils.KeyLeft) {
+	if vars.Controls.KeyDown(utils.KeyLeft) {
 		if math.Abs(p.body.Vx) <= p.body.MaxX {
 			p.body.Vx -= speed * dt
 		}
 		flip = false
 	}
-	if vars.Pad.KeyDown(utils.KeyRight) {
+	if vars.Controls.KeyDown(utils.KeyRight) {
 		if math.Abs(p.body.Vx) <= p.body.MaxX {
 			p.body.Vx += speed * dt
 		}
@@ -168,10 +206,11 @@ func (p *Player) input(dt float64) {
 	if !p.BlockingState() {
 		p.anim.FlipX = flip
 	}
-	if vars.Pad.KeyPressed(utils.KeyJump) && p.CanJump() {
+	if vars.Controls.KeyPressed(utils.KeyJump) && p.CanJump() {
 		p.ClimbOff()
 		p.stats.AddStamina(-jumpingStamina)
 		p.body.Vy = -p.jumpSpeed
+		vars.Audio.Play("jump")
 	}
 
 	if vars.Debug {
@@ -182,8 +221,8 @@ func (p *Player) input(dt float64) {
 }
 
 func (p *Player) inputClimbing(dt float64) {
-	if vars.Pad.KeyDown(utils.KeyUp) || vars.Pad.KeyDown(utils.KeyDown) {
-		p.ClimbOn(vars.Pad.KeyDown(utils.KeyDown))
+	if vars.Controls.KeyDown(utils.KeyUp) || vars.Controls.KeyDown(utils.KeyDown) {
+		p.ClimbOn(vars.Controls.KeyDown(utils.KeyDown))
 	}
 	if p.anim.State != vars.ClimbTag {
 		return
@@ -195,17 +234,17 @@ func (p *Player) inputClimbing(dt float64) {
 	}
 	speed := p.speed * playerClimbSpeed * dt
 	p.body.Vx = 0
-	if vars.Pad.KeyDown(utils.KeyLeft) {
+	if vars.Controls.KeyDown(utils.KeyLeft) {
 		p.body.Vx = -speed
 	}
-	if vars.Pad.KeyDown(utils.KeyRight) {
+	if vars.Controls.KeyDown(utils.KeyRight) {
 		p.body.Vx = speed
 	}
 	p.body.Vy = 0
-	if vars.Pad.KeyDown(utils.KeyUp) {
+	if vars.Controls.KeyDown(utils.KeyUp) {
 		p.body.Vy = -speed
 	}
-	if vars.Pad.KeyDown(utils.KeyDown) {
+	if vars.Controls.KeyDown(utils.KeyDown) {
 		p.body.Vy = speed
 	}
 }
@@ -0,0 +1,184 @@
+package entity
+
+import (
+	"game/comps/body"
+	"game/comps/faction"
+	"game/comps/hitbox"
+	"game/comps/render"
+	"game/comps/stats"
+	"game/core"
+	"game/entity/actor"
+	"game/libs/bump"
+	"game/vars"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type ProjectileConfig struct {
+	Image        *ebiten.Image
+	Damage       float64
+	GravityScale float64
+	MaxSpeed     float64 // Defaults to the shooting speed.
+	Lifetime     float64 // Seconds before vanishing, 0 lasts until it hits something.
+	Pierce       int     // How many hits it goes through, -1 for all of them.
+	Homing       float64 // How fast it turns toward the owner target, in radians per second.
+	Reflectable  bool    // Parrying sends it back toward its owner.
+	Rotate       bool    // Points the image along the velocity.
+	RollingTime  time.Duration
+	Effects      []*stats.Effect
+}
+
+// Projectile flies from its owner, hitting through hitbox.HitFromHitBox like any attack, so it can be blocked and
+// parried. Walls and the ground stop it.
+type Projectile struct {
+	*core.BaseEntity
+	render         *render.Comp
+	body           *body.Comp
+	hitbox         *hitbox.Comp
+	faction        *faction.Comp
+	config         *ProjectileConfig
+	owner          core.Entity
+	target         core.Entity
+	hit            []*hitbox.Comp
+	pierce         int
+	lifetime       float64
+	prevVx, prevVy float64
+}
+
+func NewProjectile(x, y, vx, vy float64, owner core.Entity, config *ProjectileConfig) *Projectile {
+	size := config.Image.Bounds().Size()
+	speed := math.Hypot(vx, vy)
+	projectile := &Projectile{
+		BaseEntity: &core.BaseEntity{X: x, Y: y, W: float64(size.X), H: float64(size.Y)},
+		render:     &render.Comp{Image: config.Image, RollingTime: config.RollingTime},
+		body: &body.Comp{
+			Vx: vx, Vy: vy,
+			MaxX:      math.Max(config.MaxSpeed, speed),
+			MaxY:      math.Max(vars.DefaultMaxY, speed),
+			Tags:      []bump.Tag{},
+			QueryTags: []bump.Tag{"map", "solid"},
+			FilterOut: []core.Entity{owner},
+		},
+		hitbox:   &hitbox.Comp{Effects: config.Effects},
+		faction:  &faction.Comp{},
+		config:   config,
+		pierce:   config.Pierce,
+		lifetime: config.Lifetime,
+	}
+	projectile.Add(projectile.render, projectile.body, projectile.hitbox, projectile.faction)
+	projectile.setOwner(owner)
+
+	return projectile
+}
+
+// ShootFromSlice plays the tag anim of owner, firing a projectile from the center of the slice the first frame it
+// shows up. The velocity is the one facing right, mirrored when owner faces left.
+func ShootFromSlice(owner actor.Actor, tag, sliceName string, vx, vy float64, config *ProjectileConfig) {
+	anim, _, _, _, _ := owner.Comps()
+	anim.SetState(tag)
+	anim.OnSlicePresent(sliceName, func(slice bump.Rect, segmented bool) {
+		if !segmented {
+			return
+		}
+		x, y := owner.Position()
+		size := config.Image.Bounds().Size()
+		x += slice.X + (slice.W-float64(size.X))/2
+		y += slice.Y + (slice.H-float64(size.Y))/2
+		shotVx := vx
+		if !anim.FlipX {
+			shotVx = -vx
+		}
+		vars.World.Add(NewProjectile(x, y, shotVx, vy, owner, config))
+	})
+}
+
+func (p *Projectile) Init() {
+	p.body.Friction = false
+	p.body.Weight = p.config.GravityScale
+	p.prevVx, p.prevVy = p.body.Vx, p.body.Vy
+	p.hitbox.HitFunc = func(other core.Entity, _ *bump.Collision, _ float64, _ hitbox.ContactType) {
+		if other != p.owner {
+			vars.World.Remove(p)
+		}
+	}
+	p.hitbox.PushHitbox(bump.Rect{W: p.W, H: p.H}, hitbox.Hit, nil)
+}
+
+func (p *Projectile) Update(dt float64) {
+	stopped := (p.prevVx != 0 && p.body.Vx == 0) || (p.prevVy < 0 && p.body.Vy == 0) || p.body.Ground
+	if p.lifetime -= dt; stopped || (p.config.Lifetime > 0 && p.lifetime <= 0) {
+		vars.World.Remove(p)
+
+		return
+	}
+
+	filterOut := slices.Clone(p.hit)
+	contact, contacted := p.hitbox.HitFromHitBox(bump.Rect{W: p.W, H: p.H}, p.config.Damage, filterOut)
+	if newHits := contacted[len(filterOut):]; len(newHits) > 0 || contact >= hitbox.Block {
+		switch {
+		case len(newHits) == 0:
+			// Blocked by a wall.
+			vars.World.Remove(p)
+
+			return
+		case contact == hitbox.ParryBlock && p.config.Reflectable:
+			p.reflect(newHits[len(newHits)-1].Entity())
+		case contact >= hitbox.Block:
+			vars.World.Remove(p)
+
+			return
+		default:
+			p.hit = append(p.hit, newHits...)
+			if p.pierce -= len(newHits); p.config.Pierce >= 0 && p.pierce < 0 {
+				vars.World.Remove(p)
+
+				return
+			}
+		}
+	}
+
+	p.home(dt)
+	if p.config.Rotate {
+		p.render.R = math.Atan2(p.body.Vy, p.body.Vx)
+	}
+	p.prevVx, p.prevVy = p.body.Vx, p.body.Vy
+}
+
+// reflect sends the projectile back the way it came, now owned by whoever parried it.
+func (p *Projectile) reflect(parrier core.Entity) {
+	p.body.Vx, p.body.Vy = -p.body.Vx, -p.body.Vy
+	p.target = p.owner
+	p.setOwner(parrier)
+}
+
+func (p *Projectile) setOwner(owner core.Entity) {
+	p.owner = owner
+	p.faction.Faction = faction.Of(owner)
+	p.body.FilterOut = []core.Entity{owner}
+	p.hit = nil
+	if ownerHitbox := core.Get[*hitbox.Comp](owner); ownerHitbox != nil {
+		p.hit = []*hitbox.Comp{ownerHitbox}
+	}
+	if shooter, ok := owner.(actor.Actor); ok && p.target == nil {
+		_, _, _, _, ownerAI := shooter.Comps()
+		if ownerAI != nil {
+			p.target = ownerAI.Target
+		}
+	}
+}
+
+func (p *Projectile) home(dt float64) {
+	if p.config.Homing == 0 || p.target == nil {
+		return
+	}
+	tx, ty, tw, th := p.target.Rect()
+	x, y := p.Position()
+	speed := math.Hypot(p.body.Vx, p.body.Vy)
+	angle := math.Atan2(p.body.Vy, p.body.Vx)
+	turn := math.Remainder(math.Atan2(ty+th/2-y-p.H/2, tx+tw/2-x-p.W/2)-angle, 2*math.Pi)
+	angle += math.Max(-p.config.Homing*dt, math.Min(p.config.Homing*dt, turn))
+	p.body.Vx, p.body.Vy = speed*math.Cos(angle), speed*math.Sin(angle)
+}
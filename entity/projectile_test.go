@@ -0,0 +1,187 @@
+package entity_test
+
+import (
+	"game/comps/ai"
+	"game/comps/hitbox"
+	"game/comps/stats"
+	"game/core"
+	"game/core/coretest"
+	"game/entity"
+	"game/libs/bump"
+	"game/vars"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// newIdleEnemy makes a skeleman sized enemy at x, y without any AI.
+func newIdleEnemy(x, y float64) *entity.Enemy {
+	def := &entity.EnemyDefinition{Anim: "skeleman", W: 8, H: 12, Health: 10}
+
+	return entity.NewEnemy(def, x, y, &core.Properties{Custom: map[string]string{"ai": "none"}})
+}
+
+func TestShootFromSlice(t *testing.T) {
+	coretest.NewWorld()
+	shooter := newIdleEnemy(100, 0)
+	vars.World.Add(shooter)
+	vars.World.Camera.Follow(shooter)
+	vars.World.Update(0)
+
+	entity.ShootFromSlice(shooter, "AttackShort", "hitbox", 100, 0, &entity.ProjectileConfig{Image: ebiten.NewImage(2, 2)})
+	startX := map[*entity.Projectile]float64{}
+	for range 180 {
+		vars.World.Update(1.0 / 60)
+		for _, e := range vars.World.GetAll() {
+			if shot, ok := e.(*entity.Projectile); ok {
+				if _, seen := startX[shot]; !seen {
+					startX[shot], _ = shot.Position()
+				}
+			}
+		}
+	}
+
+	if len(startX) != 1 {
+		t.Fatalf("%d projectiles shot, want 1", len(startX))
+	}
+	// The shooter faces left, so the shot flies that way.
+	for shot, x := range startX {
+		if shotX, _ := shot.Position(); shotX >= x {
+			t.Errorf("projectile went from x %v to %v, want it flying left", x, shotX)
+		}
+	}
+}
+
+// newShootingRange makes a world with a floor at y 62, returning an enemy standing on it at x.
+func newShootingRange() func(x float64) *entity.Enemy {
+	coretest.NewWorld()
+	floor := bump.Rect{X: -500, Y: 62, W: 1000, H: 10}
+	vars.World.Space.Set(&floor, floor, "map")
+
+	return func(x float64) *entity.Enemy {
+		enemy := newIdleEnemy(x, 50)
+		vars.World.Add(enemy)
+
+		return enemy
+	}
+}
+
+// fly runs the world for the given seconds, reporting whether shot is still in it.
+func fly(shot *entity.Projectile, seconds float64) bool {
+	for range int(seconds * 60) {
+		vars.World.Update(1.0 / 60)
+	}
+
+	return slices.Contains(vars.World.GetAll(), core.Entity(shot))
+}
+
+func TestProjectilePierce(t *testing.T) {
+	for pierce, want := range map[int][]float64{0: {9, 10, 10}, 1: {9, 9, 10}, -1: {9, 9, 9}} {
+		newEnemy := newShootingRange()
+		player := entity.NewPlayer(0, 50, entity.PlayerLevels{})
+		vars.World.Add(player)
+		vars.World.Camera.Follow(player)
+		targets := []*entity.Enemy{newEnemy(40), newEnemy(60), newEnemy(80)}
+		config := &entity.ProjectileConfig{Image: ebiten.NewImage(2, 2), Damage: 1, Pierce: pierce}
+		vars.World.Add(entity.NewProjectile(20, 55, 100, 0, player, config))
+		vars.World.Update(0)
+		for range 60 {
+			vars.World.Update(1.0 / 60)
+		}
+
+		var health []float64
+		for _, target := range targets {
+			health = append(health, core.Get[*stats.Comp](target).Health)
+		}
+		if !slices.Equal(health, want) {
+			t.Errorf("health of the targets after a shot piercing %d = %v, want %v", pierce, health, want)
+		}
+	}
+}
+
+func TestProjectileHoming(t *testing.T) {
+	newEnemy := newShootingRange()
+	shooter := newEnemy(0)
+	player := entity.NewPlayer(100, 0, entity.PlayerLevels{})
+	vars.World.Add(player)
+	vars.World.Camera.Follow(shooter)
+	core.Get[*ai.Comp](shooter).Target = player
+	config := &entity.ProjectileConfig{Image: ebiten.NewImage(2, 2), Homing: 3}
+	shot := entity.NewProjectile(10, 55, 100, 0, shooter, config)
+	straight := entity.NewProjectile(10, 55, 100, 0, shooter, &entity.ProjectileConfig{Image: config.Image})
+	vars.World.Add(shot)
+	vars.World.Add(straight)
+	vars.World.Update(0)
+	fly(shot, 0.3)
+
+	if _, y := shot.Position(); y >= 55 {
+		t.Errorf("homing shot at y %v, want it turned up toward the target", y)
+	}
+	if _, y := straight.Position(); y != 55 {
+		t.Errorf("shot without homing at y %v, want it flying straight at 55", y)
+	}
+}
+
+func TestProjectileReflect(t *testing.T) {
+	newEnemy := newShootingRange()
+	shooter := newEnemy(0)
+	player := entity.NewPlayer(60, 51, entity.PlayerLevels{})
+	vars.World.Add(player)
+	vars.World.Camera.Follow(shooter)
+	vars.World.Update(0)
+	_, _, playerW, playerH := player.Rect()
+	core.Get[*hitbox.Comp](player).PushHitbox(bump.Rect{W: playerW, H: playerH}, hitbox.ParryBlock, nil)
+
+	config := &entity.ProjectileConfig{Image: ebiten.NewImage(2, 2), Damage: 1, Reflectable: true}
+	shot := entity.NewProjectile(20, 55, 100, 0, shooter, config)
+	vars.World.Add(shot)
+	fly(shot, 1)
+
+	// Owned by the player, the shot can hit the enemy that shot it.
+	if health := core.Get[*stats.Comp](shooter).Health; health != 9 {
+		t.Errorf("shooter health %v after its parried shot came back, want 9", health)
+	}
+	// Parrying only chips the health blocking takes.
+	if playerStats := core.Get[*stats.Comp](player); playerStats.Health <= playerStats.MaxHealth-config.Damage {
+		t.Errorf("player health %v of %v after parrying, want it not hit", playerStats.Health, playerStats.MaxHealth)
+	}
+}
+
+func TestProjectileLifetime(t *testing.T) {
+	newShootingRange()
+	owner := entity.NewPlayer(0, 50, entity.PlayerLevels{})
+	vars.World.Add(owner)
+	vars.World.Camera.Follow(owner)
+	shot := entity.NewProjectile(10, 20, 10, 0, owner, &entity.ProjectileConfig{Image: ebiten.NewImage(2, 2), Lifetime: 0.5})
+	vars.World.Add(shot)
+	vars.World.Update(0)
+
+	if !fly(shot, 0.4) {
+		t.Fatal("shot gone before its lifetime ran out")
+	}
+	if fly(shot, 0.2) {
+		t.Error("shot still flying once its lifetime ran out")
+	}
+}
+
+func TestProjectileGravity(t *testing.T) {
+	newShootingRange()
+	owner := entity.NewPlayer(0, 50, entity.PlayerLevels{})
+	vars.World.Add(owner)
+	vars.World.Camera.Follow(owner)
+	image := ebiten.NewImage(2, 2)
+	falling := entity.NewProjectile(10, 0, 10, 0, owner, &entity.ProjectileConfig{Image: image, GravityScale: 1})
+	floating := entity.NewProjectile(10, 10, 10, 0, owner, &entity.ProjectileConfig{Image: image})
+	vars.World.Add(falling)
+	vars.World.Add(floating)
+	vars.World.Update(0)
+	fly(falling, 0.2)
+
+	if _, y := falling.Position(); y <= 0 {
+		t.Errorf("shot with gravity at y %v, want it falling from 0", y)
+	}
+	if _, y := floating.Position(); y != 10 {
+		t.Errorf("shot without gravity at y %v, want it staying at 10", y)
+	}
+}
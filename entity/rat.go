@@ -2,144 +2,80 @@ package entity
 
 import (
 	"game/comps/ai"
-	"game/comps/anim"
-	"game/comps/body"
-	"game/comps/hitbox"
-	"game/comps/stats"
 	"game/core"
-	"game/entity/actor"
-	"game/libs/bump"
 	"game/vars"
 )
 
-const (
-	ratWidth, ratHeight                   = 11, 7
-	ratOffsetX, ratOffsetY, ratOffsetFlip = -13, -17, 21
-	ratSpeed, ratMaxSpeed                 = 60.0, 40
-	ratWeight                             = 0.6
-	ratHealth                             = 25
-	ratDamage                             = 15
-	ratExp                                = 15
-	ratPoise                              = 10
-)
-
-type Rat struct {
-	*core.BaseEntity
-	*actor.Control
-	anim      *anim.Comp
-	body      *body.Comp
-	hitbox    *hitbox.Comp
-	stats     *stats.Comp
-	ai        *ai.Comp
-	jumpFrame int
-}
-
-func NewRat(x, y, _, _ float64, props *core.Properties) *Rat {
-	rat := &Rat{
-		BaseEntity: &core.BaseEntity{X: x, Y: y, W: ratWidth, H: ratHeight},
-		anim: &anim.Comp{
-			FilesName: "rat",
-			OX:        ratOffsetX, OY: ratOffsetY,
-			OXFlip: ratOffsetFlip,
-			FlipX:  props.FlipX,
-		},
-		body:   &body.Comp{Weight: ratWeight},
-		hitbox: &hitbox.Comp{},
-		stats:  &stats.Comp{MaxHealth: ratHealth, MaxPoise: ratPoise, Exp: ratExp},
-		ai:     &ai.Comp{},
-	}
-	rat.Add(rat.anim, rat.body, rat.hitbox, rat.stats, rat.ai)
-	rat.Control = actor.NewControl(rat)
-
-	var view *bump.Rect
-	if props.View != nil {
-		viewRect := bump.NewRect(props.View.X, props.View.Y, props.View.Width, props.View.Height)
-		view = &viewRect
-	}
-	rat.ai.SetAct(func() { rat.aiScript(view) })
-
-	return rat
-}
+func init() { RegisterEnemyHooks("rat", newRatHooks, "pounce") }
 
-func (r *Rat) Comps() (anim *anim.Comp, body *body.Comp, hitbox *hitbox.Comp, stats *stats.Comp, ai *ai.Comp) {
-	return r.anim, r.body, r.hitbox, r.stats, r.ai
-}
+// newRatHooks adds the pounce, leaping on the frame the Jump animation starts within the Attack one, and the Jump
+// animation while in the air.
+func newRatHooks(e *Enemy, _ *core.Properties) *EnemyHooks {
+	var jumpFrame int
 
-func (r *Rat) Init() {
-	r.Control.Init()
-	r.jumpFrame = r.anim.Data.Animation("Jump").From - r.anim.Data.Animation("Attack").From
-}
+	return &EnemyHooks{
+		Actions: map[string]func(def ActionDefinition) *ai.Action{
+			"pounce": func(def ActionDefinition) *ai.Action {
+				speed, maxSpeed, damage := e.actionParams(def)
 
-func (r *Rat) Update(dt float64) {
-	r.SimpleUpdate(dt)
-	if !r.body.Ground && (r.anim.State == vars.IdleTag || r.anim.State == vars.WalkTag) {
-		r.anim.SetState("Jump")
-	}
-	if r.body.Ground && r.anim.State == "Jump" {
-		r.anim.SetState(vars.IdleTag)
+				return ratPounceAction(e, jumpFrame, speed, maxSpeed, damage)
+			},
+		},
+		Init: func() { jumpFrame = e.anim.Data.Animation("Jump").From - e.anim.Data.Animation("Attack").From },
+		Update: func(dt float64) {
+			e.SimpleUpdate(dt)
+			if !e.body.Ground && (e.anim.State == vars.IdleTag || e.anim.State == vars.WalkTag) {
+				e.anim.SetState("Jump")
+			}
+			if e.body.Ground && e.anim.State == "Jump" {
+				e.anim.SetState(vars.IdleTag)
+			}
+		},
 	}
 }
 
-func (r *Rat) jumpAttackAction() *ai.Action {
-	speed := ratSpeed
+func ratPounceAction(e *Enemy, jumpFrame int, speed, maxSpeed, damage float64) *ai.Action {
 	jumped, lifted := false, false
 
 	return &ai.Action{
 		Name: "JumpAttack",
 		Entry: func() {
-			if r.PausingState() {
+			if e.PausingState() {
 				return
 			}
-			r.Control.Attack("Attack", ratDamage, ratDamage, 10, 10)
-			r.anim.OnFrame(r.jumpFrame, func() {
+			e.Attack("Attack", damage, damage, 10, 10)
+			e.anim.OnFrame(jumpFrame, func() {
 				jumped = true
-				r.body.MaxX = ratMaxSpeed * 2
-				r.body.Vy = -ratSpeed
-				r.body.Ground = false
-				if r.anim.FlipX {
-					r.body.Vx += ratMaxSpeed * 2
+				e.body.MaxX = maxSpeed * 2
+				e.body.Vy = -speed
+				e.body.Ground = false
+				if e.anim.FlipX {
+					e.body.Vx += maxSpeed * 2
 				} else {
-					r.body.Vx -= ratMaxSpeed * 2
+					e.body.Vx -= maxSpeed * 2
 					speed *= -1
 				}
 			})
 		},
 		Next: func(dt float64) bool {
-			if r.anim.State != "Attack" {
+			if e.anim.State != "Attack" {
 				return true
 			}
-			if !r.body.Ground {
+			if !e.body.Ground {
 				lifted = true
 			}
 			if !jumped || !lifted {
 				return false
 			}
-			if !r.PausingState() {
-				r.body.Vx += speed * dt
+			if !e.PausingState() {
+				e.body.Vx += speed * dt
 			}
-			if r.body.Ground {
-				r.anim.SetState(vars.IdleTag)
+			if e.body.Ground {
+				e.anim.SetState(vars.IdleTag)
 			}
 
 			return false
 		},
-		Exit: func() { r.body.MaxX = ratMaxSpeed },
+		Exit: func() { e.body.MaxX = maxSpeed },
 	}
 }
-
-//nolint:mnd
-func (r *Rat) aiScript(view *bump.Rect) {
-	rangeAdjustment := 10.0
-	r.ai.Add(0, actor.IdleAction(r.Control, view))
-	r.ai.Add(0, actor.ApproachAction(r.Control, ratSpeed, ratMaxSpeed, rangeAdjustment))
-	r.ai.Add(0.1, actor.WaitAction())
-
-	ai.Choices{
-		{2, func() {
-			r.ai.Add(3, r.jumpAttackAction())
-			r.ai.Add(0.5, actor.WaitAction())
-		}},
-		{0.5, func() { r.ai.Add(1, actor.BackUpAction(r.Control, ratSpeed, -rangeAdjustment)) }},
-		{1, func() { r.ai.Add(0.5, actor.WaitAction()) }},
-	}.Play()
-}
@@ -2,12 +2,7 @@ package entity
 
 import (
 	"game/assets"
-	"game/comps/body"
-	"game/comps/hitbox"
-	"game/comps/render"
-	"game/core"
 	"game/entity/actor"
-	"game/libs/bump"
 	"game/vars"
 	"math"
 	"time"
@@ -16,7 +11,6 @@ import (
 )
 
 const (
-	rockSize               = 5
 	rockDamage             = 5
 	rockWeight             = 0.6
 	rockMinVel, rockMaxVel = 30.0, 100.0
@@ -25,60 +19,25 @@ const (
 
 var (
 	rockImage, _, _ = ebitenutil.NewImageFromFileSystem(assets.FS, "rock.png")
+	rockConfig      = &ProjectileConfig{
+		Image:        rockImage,
+		Damage:       rockDamage,
+		GravityScale: rockWeight,
+		MaxSpeed:     rockMaxVel,
+		RollingTime:  rockRollingTime,
+	}
 )
 
-type Rock struct {
-	*core.BaseEntity
-	render      *render.Comp
-	body        *body.Comp
-	hitbox      *hitbox.Comp
-	owner       actor.Actor
-	ownerHitbox *hitbox.Comp
-}
-
-func NewRock(x, y float64, owner actor.Actor) *Rock {
-	_, _, ownerHitbox, _, ownerAI := owner.Comps()
+// NewRock throws a rock in an arc landing around the owner target.
+func NewRock(x, y float64, owner actor.Actor) *Projectile {
+	_, _, _, _, ownerAI := owner.Comps()
 	vx, vy := rockMaxVel, 60.0
 	if target := ownerAI.Target; target != nil {
 		tx, ty := target.Position()
 		vx = calculateVx(x, y, tx, ty, vy)
 	}
 
-	rock := &Rock{
-		BaseEntity: &core.BaseEntity{X: x, Y: y, W: rockSize, H: rockSize},
-		render:     &render.Comp{Image: rockImage, RollingTime: rockRollingTime},
-		body: &body.Comp{
-			Weight: rockWeight,
-			Vx:     vx, Vy: -vy,
-			MaxX:      rockMaxVel,
-			FilterOut: []core.Entity{owner},
-		},
-		hitbox:      &hitbox.Comp{},
-		owner:       owner,
-		ownerHitbox: ownerHitbox,
-	}
-	rock.Add(rock.render, rock.body, rock.hitbox)
-
-	return rock
-}
-
-func (r *Rock) Init() {
-	r.body.Friction = false
-	r.hitbox.HitFunc = r.RockHurt
-	r.hitbox.PushHitbox(bump.Rect{W: rockSize, H: rockSize}, hitbox.Hit, nil)
-}
-
-func (r *Rock) Update(_ float64) {
-	_, contacted := r.hitbox.HitFromHitBox(bump.Rect{H: rockSize, W: rockSize}, rockDamage, []*hitbox.Comp{r.ownerHitbox})
-	if len(contacted) > 1 || r.body.Ground {
-		vars.World.Remove(r)
-	}
-}
-
-func (r *Rock) RockHurt(other core.Entity, _ *bump.Collision, _ float64, _ hitbox.ContactType) {
-	if other != r.owner {
-		vars.World.Remove(r)
-	}
+	return NewProjectile(x, y, vx, -vy, owner, rockConfig)
 }
 
 func calculateVx(x, y, tx, ty, vy float64) float64 {
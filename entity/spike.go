@@ -2,32 +2,34 @@ package entity
 
 import (
 	"game/assets"
+	"game/comps/body"
 	"game/comps/hitbox"
 	"game/comps/render"
 	"game/core"
 	"game/ext"
 	"game/libs/bump"
+	"game/vars"
 	"slices"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 const (
 	spikeDamage = 20
-	spikeTimer  = 2 * time.Second
+	spikeTimer  = 2
+	// hazardTag marks the bodies the player can't safely stand on.
+	hazardTag bump.Tag = "hazard"
 )
 
-var (
-	spikeImage, _, _  = ebitenutil.NewImageFromFileSystem(assets.FS, "spike.png")
-	spikeContactTimer = map[*hitbox.Comp]time.Time{}
-	spikeContacted    []*hitbox.Comp
-)
+var spikeImage, _, _ = ebitenutil.NewImageFromFileSystem(assets.FS, "spike.png")
 
 type Spike struct {
 	*core.BaseEntity
 	render *render.Comp
 	hitbox *hitbox.Comp
+	body   *body.Comp
+	// contacted are the hitboxes hit less than spikeTimer ago, left alone until then.
+	contacted []*hitbox.Comp
 }
 
 func NewSpike(x, y, _, _ float64, props *core.Properties) *Spike {
@@ -35,8 +37,9 @@ func NewSpike(x, y, _, _ float64, props *core.Properties) *Spike {
 		BaseEntity: &core.BaseEntity{X: x + 1, Y: y, W: tileSize - 3, H: tileSize},
 		render:     &render.Comp{X: -2, Image: spikeImage, FlipX: props.FlipX, FlipY: props.FlipY, Layer: core.LayerIndex},
 		hitbox:     &hitbox.Comp{},
+		body:       &body.Comp{NoUpdate: true, Unmovable: true, Tags: []bump.Tag{hazardTag}},
 	}
-	spike.Add(spike.render, spike.hitbox)
+	spike.Add(spike.render, spike.hitbox, spike.body)
 
 	return spike
 }
@@ -44,27 +47,20 @@ func NewSpike(x, y, _, _ float64, props *core.Properties) *Spike {
 func (s *Spike) Init() {}
 
 func (s *Spike) Update(dt float64) {
-	for c, t := range spikeContactTimer {
-		if elapsed := time.Since(t); elapsed > spikeTimer {
-			delete(spikeContactTimer, c)
-			i := slices.Index(spikeContacted, c)
-			l := len(spikeContacted) - 1
-			spikeContacted[i], spikeContacted = spikeContacted[l], spikeContacted[:l]
-		}
-	}
-
 	area := bump.NewRect(s.Rect())
 	if len(ext.QueryItems[core.Entity](nil, area, "body")) == 0 {
 		return
 	}
 
 	area.X, area.Y = 0, 0
-	_, contacted := s.hitbox.HitFromHitBox(area, spikeDamage, spikeContacted)
+	_, contacted := s.hitbox.HitFromHitBox(area, spikeDamage, s.contacted)
 	for _, c := range contacted {
-		if _, ok := spikeContactTimer[c]; ok {
+		if slices.Contains(s.contacted, c) {
 			continue
 		}
-		spikeContactTimer[c] = time.Now()
-		spikeContacted = append(spikeContacted, c)
+		s.contacted = append(s.contacted, c)
+		vars.World.Scheduler.After(s, spikeTimer, func() {
+			s.contacted = slices.DeleteFunc(s.contacted, func(other *hitbox.Comp) bool { return other == c })
+		})
 	}
 }
@@ -5,11 +5,12 @@ import (
 	"game/core"
 	"game/vars"
 	"image/color"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
+const startDoorOpenDelay = 2
+
 var backgroundColor = color.RGBA{50, 60, 57, 255}
 
 func init() { core.RegisterEntityName("StartDoor", NewStartDoor) }
@@ -28,17 +29,16 @@ func NewStartDoor(x, y, w, h float64, _ *core.Properties) *StartDoor {
 		render:     &render.Comp{Image: image},
 	}
 	door.Add(door.render)
-	time.AfterFunc(2*time.Second, func() {
-		vars.World.Camera.Shake(0.1, 0.1)
-		for range 10 {
-			vars.World.Add(NewSmoke(door))
-		}
-		vars.World.Remove(door)
-	})
 
 	return door
 }
 
-func (sd *StartDoor) Init() {}
+func (sd *StartDoor) Init() {
+	vars.World.Scheduler.After(sd, startDoorOpenDelay, func() {
+		vars.World.Camera.Shake(0.1, 0.1)
+		EmitSmoke(sd, 10)
+		vars.World.Remove(sd)
+	})
+}
 
 func (sd *StartDoor) Update(float64) {}
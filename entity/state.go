@@ -0,0 +1,31 @@
+package entity
+
+import "encoding/json"
+
+type openState struct {
+	Open bool `json:"open"`
+	// Unclaimed is set while the item dropped on opening is still to be picked up.
+	Unclaimed bool `json:"unclaimed,omitempty"`
+}
+
+// saveOpenState keeps whether the entity is open, along with the item it drops when there's one.
+func saveOpenState(open bool, drop *itemDrop) any {
+	if !open {
+		return nil
+	}
+
+	return openState{Open: true, Unclaimed: drop.unclaimed()}
+}
+
+func loadOpenState(data json.RawMessage, open func(), drop *itemDrop) error {
+	var state openState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	if state.Open {
+		open()
+		drop.load(state.Unclaimed)
+	}
+
+	return nil
+}
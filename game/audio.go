@@ -0,0 +1,61 @@
+package game
+
+import (
+	"game/assets"
+	"game/core"
+	"game/libs/bump"
+	"game/libs/sound"
+	"game/vars"
+	"log"
+)
+
+const (
+	soundsDir    = "sounds"
+	defaultMusic = "ambient"
+)
+
+type musicArea struct {
+	rect  bump.Rect
+	music string
+}
+
+// musicAreas are the rooms with a music property, every other one playing the default music.
+var musicAreas []musicArea
+
+// loadAudio sets up the audio manager on the sound card, unless one is already set like in headless runs.
+func loadAudio(sink sound.Sink) {
+	if vars.Audio != nil && sink == nil {
+		return
+	}
+	if sink == nil {
+		sink = sound.NewContextSink()
+	}
+	sounds, err := sound.LoadAll(assets.SoundsFS, soundsDir)
+	if err != nil {
+		log.Panicln("error loading sounds:", err)
+	}
+	vars.Audio = sound.NewManager(sink, sounds)
+	applyAudioConfig()
+}
+
+func loadMusicAreas(worldMap *core.Map, roomsLayer string) {
+	musicAreas = nil
+	for _, room := range worldMap.GetObjects(roomsLayer) {
+		if music := room.Properties.GetString("music"); music != "" {
+			musicAreas = append(musicAreas, musicArea{bump.Rect{X: room.X, Y: room.Y, W: room.Width, H: room.Height}, music})
+		}
+	}
+}
+
+// playRoomMusic plays the music of the room the camera entered.
+func playRoomMusic(event core.RoomEntered) {
+	music := defaultMusic
+	for _, area := range musicAreas {
+		if area.rect == event.Room {
+			music = area.music
+
+			break
+		}
+	}
+	vars.Audio.PlayMusic(music)
+}
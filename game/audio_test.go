@@ -0,0 +1,20 @@
+package game
+
+import (
+	"game/utils"
+	"slices"
+	"testing"
+)
+
+func TestSounds(t *testing.T) {
+	h := NewHeadless(1, false, nil)
+	h.Input.Press(90, utils.KeyJump)
+	if err := h.Run(200); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"ambient", "jump"} {
+		if !slices.Contains(h.Sounds.Played, name) {
+			t.Errorf("sound %s not played, played: %v", name, h.Sounds.Played)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"game/comps/stats"
+	"game/core"
+	"game/entity"
+	"game/vars"
+	"math"
+)
+
+// bloodstainID is the ID the stain is kept under in the states of its map, out of the range of Tiled object IDs.
+const bloodstainID = math.MaxUint32
+
+// dropBloodstain saves the Exp gained since the last save where the player last stood on the ground, replacing any
+// previous stain.
+func dropBloodstain() error {
+	return updateSave(func(sd *SaveData) error {
+		for _, states := range sd.States {
+			delete(states, bloodstainID)
+		}
+		exp := core.Get[*stats.Comp](vars.Player).Exp - sd.PlayerData.Exp
+		if exp <= 0 {
+			return nil
+		}
+		x, floorY := vars.Player.(*entity.Player).Ground()
+		state, err := core.NewEntityState(entity.NewBloodstain(x, floorY, exp))
+		if err != nil {
+			return err
+		}
+		if sd.States == nil {
+			sd.States = map[string]map[uint]core.EntityState{}
+		}
+		if sd.States[mapPath] == nil {
+			sd.States[mapPath] = map[uint]core.EntityState{}
+		}
+		sd.States[mapPath][bloodstainID] = state
+
+		return nil
+	})
+}
+
+// addBloodstain adds an empty stain, filled by its saved state when there's one on the loaded map.
+func addBloodstain() *entity.Bloodstain {
+	stain := entity.NewBloodstain(0, 0, 0)
+	vars.World.AddWithID(stain, bloodstainID)
+
+	return stain
+}
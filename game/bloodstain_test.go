@@ -0,0 +1,61 @@
+package game
+
+import (
+	"game/comps/stats"
+	"game/core"
+	"game/entity"
+	"game/utils"
+	"game/vars"
+	"slices"
+	"testing"
+)
+
+func TestBloodstain(t *testing.T) {
+	h := NewHeadless(1, false, nil)
+	// Dying away from the respawn point leaves the stain there.
+	h.Input.Hold(10, 95, utils.KeyRight)
+	h.Input.Hold(150, 400, utils.KeyAction)
+	var groundX, groundY float64
+	h.At(100, func(_ *core.World) {
+		player := vars.Player.(*entity.Player)
+		groundX, groundY = player.Ground()
+		x, y := player.Position()
+		player.SetPosition(x, y-50)
+		playerStats := core.Get[*stats.Comp](player)
+		playerStats.Exp += 30
+		playerStats.Health = 0
+	})
+	if err := h.Run(450); err != nil {
+		t.Fatal(err)
+	}
+
+	var stains []*entity.Bloodstain
+	for _, e := range slices.Concat(vars.World.GetAll(), vars.World.GetRemoved()) {
+		if stain, ok := e.(*entity.Bloodstain); ok && stain.Exp > 0 {
+			stains = append(stains, stain)
+		}
+	}
+	if len(stains) != 1 {
+		t.Fatalf("%d bloodstains after respawning, want 1", len(stains))
+	}
+	x, y, _, stainH := stains[0].Rect()
+	if stains[0].Exp != 30 || x != groundX || y+stainH != groundY {
+		t.Errorf("bloodstain of %d Exp at %v,%v, want 30 at the last ground %v,%v", stains[0].Exp, x, y+stainH, groundX, groundY)
+	}
+	if stains[0].Taken() {
+		t.Fatal("bloodstain taken right on respawning, away from it")
+	}
+
+	// Standing on it takes it back.
+	_, _, _, playerH := vars.Player.Rect()
+	vars.Player.SetPosition(x, y+stainH-playerH)
+	if err := h.Run(30); err != nil {
+		t.Fatal(err)
+	}
+	if !stains[0].Taken() {
+		t.Fatal("bloodstain not taken by the player standing on it")
+	}
+	if exp := core.Get[*stats.Comp](vars.Player).Exp; exp != 30 {
+		t.Errorf("player has %d Exp after taking the bloodstain, want 30", exp)
+	}
+}
@@ -0,0 +1,186 @@
+package game
+
+import (
+	"fmt"
+	"game/utils"
+	"game/vars"
+	"log"
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	bindAxisThreshold = 0.7
+	bindAxisNeutral   = 0.3
+	cancelKey         = ebiten.KeyEscape
+	cancelButton      = ebiten.StandardGamepadButtonCenterLeft
+)
+
+var controlNames = [len(utils.ControlPack{})]string{
+	utils.KeyRight:  "Right",
+	utils.KeyLeft:   "Left",
+	utils.KeyUp:     "Up",
+	utils.KeyDown:   "Down",
+	utils.KeyJump:   "Jump",
+	utils.KeyAction: "Attack",
+	utils.KeyGuard:  "Guard",
+	utils.KeyHeal:   "Heal",
+	utils.KeyDash:   "Dash",
+	utils.KeyMenu:   "Menu",
+}
+
+// ControlsMenu lets the player rebind every ControlKey, writing the result to the save. Left and Right pick the binding
+// of a key to replace, past the last one adding a new binding.
+type ControlsMenu struct {
+	Menu
+	slots         [len(utils.ControlPack{})]int
+	listening     bool
+	listeningTo   utils.ControlKey
+	listeningSlot int
+	listenDelay   bool
+	axesReleased  bool
+	keys          []ebiten.Key
+	buttons       []ebiten.StandardGamepadButton
+	gamepadBuffer []ebiten.GamepadID
+}
+
+func (m *ControlsMenu) Init() {
+	m.Title = "Controls"
+	m.Items = nil
+	for key := range controlNames {
+		if key == int(utils.KeyDash) {
+			continue
+		}
+		m.Items = append(m.Items, MenuItem{
+			Label:  func() string { return controlLabel(utils.ControlKey(key), m.slots[key]) },
+			Select: func() { m.listen(utils.ControlKey(key)) },
+			Adjust: func(dir int) {
+				slots := len(vars.Pad[key]) + 1
+				m.slots[key] = (min(m.slots[key], slots-1) + dir + slots) % slots
+			},
+		})
+	}
+	m.Items = append(m.Items,
+		MenuItem{Label: staticLabel("Reset Defaults"), Select: func() { setPad(utils.NewControlPack()) }},
+		MenuItem{Label: staticLabel("Back"), Select: m.Close},
+	)
+}
+
+func (m *ControlsMenu) Update(dt float64) bool {
+	if !m.listening {
+		return m.Menu.Update(dt)
+	}
+	// Start on the next frame, so the key that selected the item isn't bound right away.
+	if m.listenDelay {
+		m.listenDelay = false
+
+		return false
+	}
+	if capture, ok := m.capture(); ok {
+		m.listening = false
+		if !capture.Cancel {
+			setPad(vars.Pad.Rebind(m.listeningTo, m.listeningSlot, capture.Binding))
+		}
+	}
+
+	return false
+}
+
+func (m *ControlsMenu) Draw(screen *ebiten.Image) {
+	title := m.Title
+	if m.listening {
+		m.Title = fmt.Sprintf("Press new %s key (Esc/Back cancels)", controlNames[m.listeningTo])
+	}
+	m.Menu.Draw(screen)
+	m.Title = title
+}
+
+func (m *ControlsMenu) listen(key utils.ControlKey) {
+	m.listening, m.listeningTo, m.listenDelay, m.axesReleased = true, key, true, false
+	m.listeningSlot = m.slots[key]
+}
+
+// capture takes the new binding or cancel from the replayed input when there's one, recording the pressed one
+// otherwise.
+func (m *ControlsMenu) capture() (utils.Capture, bool) {
+	if source, ok := utils.Input.(utils.CaptureSource); ok {
+		return source.Capture()
+	}
+	capture, ok := utils.Capture{Cancel: true}, m.cancelPressed()
+	if !ok {
+		capture.Binding, ok = m.pressedBinding()
+	}
+	if ok && utils.Recorder != nil {
+		if err := utils.Recorder.RecordCapture(capture); err != nil {
+			log.Println("input: recording stopped:", err)
+			utils.Recorder = nil
+		}
+	}
+
+	return capture, ok
+}
+
+func (m *ControlsMenu) cancelPressed() bool {
+	if inpututil.IsKeyJustPressed(cancelKey) {
+		return true
+	}
+	m.gamepadBuffer = ebiten.AppendGamepadIDs(m.gamepadBuffer[:0])
+	for _, id := range m.gamepadBuffer {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) && inpututil.IsStandardGamepadButtonJustPressed(id, cancelButton) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ControlsMenu) pressedBinding() (utils.Binding, bool) {
+	if m.keys = inpututil.AppendJustPressedKeys(m.keys[:0]); len(m.keys) > 0 {
+		return utils.KeyBinding(m.keys[0]), true
+	}
+
+	released := true
+	m.gamepadBuffer = ebiten.AppendGamepadIDs(m.gamepadBuffer[:0])
+	for _, id := range m.gamepadBuffer {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		if m.buttons = inpututil.AppendJustPressedStandardGamepadButtons(id, m.buttons[:0]); len(m.buttons) > 0 {
+			return utils.ButtonBinding(m.buttons[0]), true
+		}
+		for axis := range ebiten.StandardGamepadAxisMax + 1 {
+			value := ebiten.StandardGamepadAxisValue(id, axis)
+			if m.axesReleased && math.Abs(value) > bindAxisThreshold {
+				return utils.AxisBinding(axis, math.Copysign(1, value)), true
+			}
+			released = released && math.Abs(value) < bindAxisNeutral
+		}
+	}
+	// Sticks held since before listening are ignored until they go back to neutral.
+	m.axesReleased = m.axesReleased || released
+
+	return utils.Binding{}, false
+}
+
+// controlLabel lists the bindings of key and a last empty one to add, the one in slot between brackets.
+func controlLabel(key utils.ControlKey, slot int) string {
+	names := make([]string, 0, len(vars.Pad[key])+1)
+	for _, binding := range vars.Pad[key] {
+		names = append(names, binding.String())
+	}
+	names = append(names, "+")
+	slot = min(slot, len(names)-1)
+	names[slot] = "[" + names[slot] + "]"
+
+	return fmt.Sprintf("%-7s%s", controlNames[key], strings.Join(names, " "))
+}
+
+func setPad(pad utils.ControlPack) {
+	vars.Pad = pad
+	if err := SavePad(); err != nil {
+		log.Println("game: error saving controls:", err)
+	}
+}
@@ -45,9 +45,10 @@ type DeathTransition struct {
 	freezeTime              float64
 	fadeTween, overlayTween *gween.Tween
 	overlayImg              *ebiten.Image
-	actionKey               []ebiten.Key
 }
 
+func (t *DeathTransition) Blocking() bool { return false }
+
 func (t *DeathTransition) Init() {
 	t.freezeTime = 0.5
 	vars.World.Freeze(t.freezeTime)
@@ -55,8 +56,11 @@ func (t *DeathTransition) Init() {
 	t.fadeTween = gween.New(0, 1, 5, ease.InQuad)
 	t.overlayTween = gween.New(0, 1, 3, ease.OutQuad)
 
+	if err := dropBloodstain(); err != nil {
+		log.Println("game: error saving bloodstain:", err)
+	}
+
 	t.overlayImg, _ = textImg.SubImage(image.Rect(0, 0, vars.ScreenWidth, vars.ScreenHeight)).(*ebiten.Image)
-	t.actionKey = vars.Pad[utils.KeyAction]
 	text := "Press Attack Key to respawn"
 	op := &ebiten.DrawImageOptions{}
 	w, h := utils.TextSize(text, assets.M5x7Font)
@@ -66,29 +70,21 @@ func (t *DeathTransition) Init() {
 }
 
 func (t *DeathTransition) Update(dt float64) bool {
-	if t == nil {
-		return false
-	}
 	if t.freezeTime -= dt; t.freezeTime > 0 {
 		return false
 	}
 	t.fadeTween.Update(float32(dt))
 	t.overlayTween.Update(float32(dt))
-	for _, key := range t.actionKey {
-		if ebiten.IsKeyPressed(key) {
-			Reset()
+	if vars.Controls.KeyDown(utils.KeyAction) {
+		Reset()
 
-			return true
-		}
+		return true
 	}
 
 	return false
 }
 
 func (t *DeathTransition) Draw(screen *ebiten.Image) {
-	if t == nil {
-		return
-	}
 	overlayAlpha, _ := t.overlayTween.Update(0)
 	newScreen := ebiten.NewImage(vars.ScreenWidth, vars.ScreenHeight)
 	ops := &ebiten.DrawRectShaderOptions{Uniforms: map[string]any{"Force": overlayAlpha}, Images: [4]*ebiten.Image{screen}}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"encoding/json"
 	"game/comps/anim"
 	"game/comps/hitbox"
 	"game/comps/stats"
@@ -16,7 +17,9 @@ import (
 
 const eventsLayerName = "events"
 
-type Event func(object *tiled.Object) func() (finish bool)
+// Event sets up the event of object, returning its trigger, true once the event is done, and what to do while it's
+// pending, run once the saved states show it hasn't finished. A nil trigger leaves the event out.
+type Event func(object *tiled.Object) (trigger func() (finish bool), pending func())
 
 type emptyEntity struct {
 	core.BaseEntity
@@ -30,58 +33,110 @@ func (e *emptyEntity) Update(dt float64) {
 	}
 }
 
+// eventEntity keeps an event from triggering again once it has finished, across saves.
+type eventEntity struct {
+	emptyEntity
+	finished bool
+	onFinish func()
+	pending  func()
+}
+
+type eventState struct {
+	Finished bool `json:"finished"`
+}
+
+func (e *eventEntity) finish() {
+	if e.finished {
+		return
+	}
+	e.finished = true
+	if e.onFinish != nil {
+		e.onFinish()
+	}
+	vars.World.Remove(e)
+}
+
+func (e *eventEntity) SaveState() any {
+	if !e.finished {
+		return nil
+	}
+
+	return eventState{Finished: true}
+}
+
+func (e *eventEntity) LoadState(data json.RawMessage) error {
+	var state eventState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	if state.Finished {
+		e.finish()
+	}
+
+	return nil
+}
+
 var (
 	hitboxEntity = &emptyEntity{}
 	events       = map[string]Event{
-		"ChestSpawn": func(object *tiled.Object) func() bool {
-			id1, _ := strconv.Atoi(object.Properties.GetString("enemy1"))
-			id2, _ := strconv.Atoi(object.Properties.GetString("enemy2"))
-			id3, _ := strconv.Atoi(object.Properties.GetString("enemy3"))
-			enemy1 := vars.World.RemoveID(uint(id1))
-			enemy2 := vars.World.RemoveID(uint(id2))
-			enemy3 := vars.World.RemoveID(uint(id3))
-
-			return func() bool {
-				vars.World.AddWithID(enemy1, uint(id1))
-				vars.World.AddWithID(enemy2, uint(id2))
-				vars.World.AddWithID(enemy3, uint(id3))
+		// The enemies stay in the world with their saved states, held out until the spawn only while it's pending.
+		"ChestSpawn": func(object *tiled.Object) (func() bool, func()) {
+			var ids []uint
+			for _, name := range []string{"enemy1", "enemy2", "enemy3"} {
+				id, _ := strconv.Atoi(object.Properties.GetString(name))
+				ids = append(ids, uint(id))
+			}
+			held := map[uint]core.Entity{}
+			spawn := func() bool {
+				for _, id := range ids {
+					if enemy := held[id]; enemy != nil {
+						vars.World.AddWithID(enemy, id)
+					}
+				}
 
 				return true
 			}
+			hold := func() {
+				for _, id := range ids {
+					held[id] = vars.World.RemoveID(id)
+				}
+			}
+
+			return spawn, hold
 		},
-		"Kill": func(object *tiled.Object) func() bool {
+		"Kill": func(object *tiled.Object) (func() bool, func()) {
 			id, _ := strconv.Atoi(object.Properties.GetString("target"))
 			target := vars.World.Get(uint(id))
 			if target == nil {
-				return nil
+				return nil, nil
 			}
 			targetStats := core.Get[*stats.Comp](target)
 			if targetStats == nil {
-				return nil
+				return nil, nil
 			}
 
 			return func() bool {
 				targetStats.Health = 0
 
 				return true
-			}
+			}, nil
 		},
-		"TurnAround": func(object *tiled.Object) func() bool {
+		"TurnAround": func(object *tiled.Object) (func() bool, func()) {
 			id, _ := strconv.Atoi(object.Properties.GetString("entity"))
 			entity := vars.World.Get(uint(id))
 			if entity == nil {
-				return nil
+				return nil, nil
 			}
 			entityAnim := core.Get[*anim.Comp](entity)
 			if entityAnim == nil {
-				return nil
+				return nil, nil
 			}
 
 			return func() bool {
 				entityAnim.FlipX = !entityAnim.FlipX
 
 				return true
-			}
+			}, nil
 		},
 	}
 )
@@ -92,7 +147,7 @@ func LoadMapEvents(tileMap *core.Map) {
 		if event == nil {
 			continue
 		}
-		updateFunc := event(object)
+		updateFunc, pending := event(object)
 		if updateFunc == nil {
 			continue
 		}
@@ -104,36 +159,51 @@ func LoadMapEvents(tileMap *core.Map) {
 		if trigger == "" {
 			trigger = object.Properties.GetString("trigger")
 		}
+		entity := &eventEntity{
+			emptyEntity: emptyEntity{BaseEntity: core.BaseEntity{X: rect.X, Y: rect.Y, W: rect.W, H: rect.H}},
+			pending:     pending,
+		}
 		switch trigger {
 		case "Hit":
-			addHitbox(rect, updateFunc)
+			addHitbox(entity, rect, updateFunc)
 		case "Enter":
-			addEnterbox(rect, updateFunc)
+			addEnterbox(entity, rect, updateFunc)
 		default:
 			log.Printf("Warning: unknown event trigger '%s' for event '%s'\n", trigger, object.Name)
+
+			continue
+		}
+		vars.World.AddWithID(entity, uint(object.ID))
+	}
+}
+
+// startPendingEvents runs the pending part of the events not finished, once the saved states are loaded.
+func startPendingEvents() {
+	for _, e := range vars.World.GetAll() {
+		if event, ok := e.(*eventEntity); ok && !event.finished && event.pending != nil {
+			event.pending()
 		}
 	}
 }
 
-func addEnterbox(rect bump.Rect, enterFunc func() bool) {
-	entity := &emptyEntity{BaseEntity: core.BaseEntity{X: rect.X, Y: rect.Y, W: rect.W, H: rect.H}}
-	entity.update = func() {
-		if items := ext.QueryItems[core.Entity](nil, rect, "body"); len(items) > 0 {
+func addEnterbox(event *eventEntity, rect bump.Rect, enterFunc func() bool) {
+	event.update = func() {
+		if items := ext.QueryItems[core.Entity](nil, rect, "body"); len(items) > 0 && !event.finished {
 			if finish := enterFunc(); finish {
-				vars.World.Remove(entity)
+				event.finish()
 			}
 		}
 	}
-	vars.World.Add(entity)
 }
 
-func addHitbox(rect bump.Rect, hitFunc func() bool) {
+func addHitbox(event *eventEntity, rect bump.Rect, hitFunc func() bool) {
 	comp := &hitbox.Comp{}
 	comp.HitFunc = func(core.Entity, *bump.Collision, float64, hitbox.ContactType) {
 		if finish := hitFunc(); finish {
-			comp.Remove()
+			event.finish()
 		}
 	}
 	comp.Init(hitboxEntity)
 	comp.PushHitbox(rect, hitbox.Hit, nil)
+	event.onFinish = comp.Remove
 }
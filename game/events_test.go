@@ -0,0 +1,41 @@
+package game
+
+import (
+	"game/core"
+	"game/vars"
+	"testing"
+)
+
+func TestChestSpawnLoad(t *testing.T) {
+	const chestSpawnID = 1118
+	enemyIDs := []uint{1121, 1105, 1122}
+	h := NewHeadless(1, false, nil)
+	if err := h.Step(); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range enemyIDs {
+		if vars.World.Get(id) != nil {
+			t.Errorf("enemy %d in the world before the chest spawned it", id)
+		}
+	}
+
+	// Saved once the spawn happened, the enemies are back on load.
+	event, ok := vars.World.Get(chestSpawnID).(*eventEntity)
+	if !ok {
+		t.Fatalf("no ChestSpawn event %d in %s", chestSpawnID, startMap)
+	}
+	event.finish()
+	states, err := vars.World.SaveStates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer delete(visitedStates, mapPath)
+	visitedStates[mapPath] = states
+	loadMap(mapPath)
+	loadMapEntities(&SaveData{States: map[string]map[uint]core.EntityState{}})
+	for _, id := range enemyIDs {
+		if vars.World.Get(id) == nil {
+			t.Errorf("enemy %d missing after loading a save where the chest spawned it", id)
+		}
+	}
+}
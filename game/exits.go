@@ -0,0 +1,177 @@
+package game
+
+import (
+	"game/comps/inventory"
+	"game/comps/stats"
+	"game/core"
+	"game/entity"
+	"game/libs/bump"
+	"game/libs/nav"
+	"game/maps"
+	"game/shader"
+	"game/vars"
+	"log"
+	stdmaps "maps"
+)
+
+const (
+	roomsLayer  = "rooms"
+	exitsLayer  = "exits"
+	spawnsLayer = "spawns"
+	// startSpawn falls back on the player tile of the entities layer when the map has no spawn with that name.
+	startSpawn = "start"
+	// exitGraceSeconds the exits are ignored for after arriving, so a spawn inside an exit of its map doesn't send
+	// the player right back.
+	exitGraceSeconds = 0.5
+)
+
+// mapExit sends the player to the spawn point of another map once touched, set in Tiled as a rect of the exits
+// layer with map and spawn properties.
+type mapExit struct {
+	rect  bump.Rect
+	path  string
+	spawn string
+}
+
+var (
+	lightGIDs = []uint32{torchGID, 931, 993}
+	// mapPath is the map the world has loaded.
+	mapPath  string
+	mapExits []mapExit
+	// exitGrace is the time left before the exits work again.
+	exitGrace float64
+	// visitedStates keeps the entity states of the maps left since the last save, so they're found as they were
+	// when coming back.
+	visitedStates = map[string]map[uint]core.EntityState{}
+)
+
+// loadMap swaps the world map, collisions, navigation and lights for the ones of path, without any entity.
+func loadMap(path string) {
+	worldMap := core.NewMap(path, 1, maps.FS, vars.PipelineScreenTag, vars.PipelineNormalMapTag)
+	vars.World.Unload()
+	vars.World.SetMap(worldMap, roomsLayer)
+	vars.Audio.Camera = vars.World.Camera
+	loadMusicAreas(worldMap, roomsLayer)
+	loadMapExits(worldMap)
+	worldMap.LoadBumpObjects(vars.World.Space, "collisions")
+	vars.World.Nav = nav.Build(vars.World.Space, nav.Config{
+		Gravity: vars.Gravity, JumpSpeed: vars.DefaultJumpSpeed, AirSpeed: vars.DefaultMaxX,
+	})
+	shader.LoadLights(worldMap, lightGIDs)
+	mapPath = path
+}
+
+// loadMapEntities adds the entities and events of the loaded map, with their states restored.
+func loadMapEntities(sd *SaveData) {
+	vars.World.Map.LoadEntityObjects(vars.World, "entities", entityBinds)
+	LoadMapEvents(vars.World.Map)
+	stain := addBloodstain()
+	vars.World.Update(0)
+	states, ok := visitedStates[mapPath]
+	if !ok {
+		states = sd.States[mapPath]
+	}
+	if err := vars.World.LoadStates(states); err != nil {
+		log.Println("game: error loading world state:", err)
+	}
+	startPendingEvents()
+	if stain.Exp <= 0 {
+		vars.World.Remove(stain)
+	}
+}
+
+func loadMapExits(worldMap *core.Map) {
+	mapExits = nil
+	for _, obj := range worldMap.GetObjects(exitsLayer) {
+		path, spawn := obj.Properties.GetString("map"), obj.Properties.GetString("spawn")
+		if path == "" {
+			log.Printf("game: exit %d has no map, skipping", obj.ID)
+
+			continue
+		}
+		mapExits = append(mapExits, mapExit{bump.Rect{X: obj.X, Y: obj.Y, W: obj.Width, H: obj.Height}, path, spawn})
+	}
+}
+
+// touchedExit returns the exit the player stands in, if any.
+func touchedExit() *mapExit {
+	player := bump.NewRect(vars.Player.Rect())
+	for i := range mapExits {
+		if bump.Overlaps(mapExits[i].rect, player) {
+			return &mapExits[i]
+		}
+	}
+
+	return nil
+}
+
+// exitToTake returns the exit the player stands in once the grace after arriving is over, if any.
+func exitToTake(dt float64) *mapExit {
+	if exitGrace -= dt; exitGrace > 0 {
+		return nil
+	}
+
+	return touchedExit()
+}
+
+// spawnPoint is the position of the player for the named spawn of the loaded map, its feet on the spawn point.
+func spawnPoint(name string) (float64, float64, bool) {
+	_, _, _, h := vars.Player.Rect()
+	for _, obj := range vars.World.Map.GetObjects(spawnsLayer) {
+		if obj.Name == name {
+			return obj.X, obj.Y - h, true
+		}
+	}
+	if name == startSpawn {
+		if obj, err := vars.World.Map.FindObjectFromTileID(playerID, "entities"); err == nil {
+			return obj.X, obj.Y, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// placePlayer moves the player to the named spawn, or to x, y without one. It must run before the player is added
+// to the world.
+func placePlayer(x, y float64, spawn string) {
+	if spawn != "" {
+		if sx, sy, ok := spawnPoint(spawn); ok {
+			x, y = sx, sy
+		} else {
+			log.Printf("game: spawn %s not found in %s", spawn, mapPath)
+		}
+	}
+	vars.Player.SetPosition(x, y)
+}
+
+// travel streams in the map of exit, moving the player to its spawn with the same stats and items.
+func travel(exit mapExit) {
+	if states, err := vars.World.SaveStates(); err != nil {
+		log.Println("game: error keeping world state:", err)
+	} else {
+		visitedStates[mapPath] = states
+	}
+	saveData, err := LoadSave()
+	if err != nil {
+		log.Println("game: error loading save:", err)
+		saveData = &SaveData{}
+	}
+	previous := vars.Player
+
+	loadMap(exit.path)
+	loadMapEntities(saveData)
+
+	// A new player, re-adding the previous one would apply its charms twice.
+	previousStats := core.Get[*stats.Comp](previous)
+	vars.Player = entity.NewPlayer(0, 0, playerLevels)
+	placePlayer(0, 0, exit.spawn)
+	playerStats := core.Get[*stats.Comp](vars.Player)
+	playerStats.Exp = previousStats.Exp
+	core.Get[*inventory.Comp](vars.Player).Items = stdmaps.Clone(core.Get[*inventory.Comp](previous).Items)
+	vars.World.Add(vars.Player)
+	vars.World.Camera.Follow(vars.Player)
+	vars.World.Update(0)
+	// Once the charms raised the maxima again on init.
+	playerStats.Carry(previousStats)
+	exitGrace = exitGraceSeconds
+}
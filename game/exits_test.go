@@ -0,0 +1,107 @@
+package game
+
+import (
+	"game/comps/inventory"
+	"game/comps/stats"
+	"game/core"
+	"game/entity"
+	"game/utils"
+	"game/vars"
+	"testing"
+)
+
+const introMap = "intro/intro.tmx"
+
+// runUntilMap steps h until the world loads path, failing after frames steps.
+func runUntilMap(t *testing.T, h *Headless, path string, frames int) {
+	t.Helper()
+	for range frames {
+		if err := h.Step(); err != nil {
+			t.Fatal(err)
+		}
+		if mapPath == path {
+			return
+		}
+	}
+	t.Fatalf("map = %s after %d frames, want %s", mapPath, frames, path)
+}
+
+func TestTravel(t *testing.T) {
+	h := NewHeadless(1, false, nil)
+	if err := h.Step(); err != nil {
+		t.Fatal(err)
+	}
+	// An opened chest gives the start map a state to carry through the travels.
+	var chestID uint
+	for _, e := range vars.World.GetAll() {
+		if chest, ok := e.(*entity.Chest); ok {
+			chest.Open()
+			chestID = vars.World.GetID(chest)
+
+			break
+		}
+	}
+	if chestID == 0 {
+		t.Fatalf("no chest in %s", startMap)
+	}
+	// The charm is applied again to the player of the next map, who must not get its health twice.
+	core.Get[*inventory.Comp](vars.Player).Add("blood_charm", 1)
+	maxHealth := core.Get[*stats.Comp](vars.Player).MaxHealth
+	h.Input.Hold(h.Frame, h.Frame+400, utils.KeyLeft)
+	runUntilMap(t, h, introMap, 400)
+
+	x, y := vars.Player.Position()
+	if sx, sy, _ := spawnPoint("from_playground"); x != sx || y != sy {
+		t.Errorf("player at %v,%v, want on the from_playground spawn %v,%v", x, y, sx, sy)
+	}
+	if playerStats := core.Get[*stats.Comp](vars.Player); playerStats.Health != maxHealth ||
+		playerStats.MaxHealth != maxHealth {
+		t.Errorf("health %v of %v after travelling, want it full at %v", playerStats.Health, playerStats.MaxHealth, maxHealth)
+	}
+	if len(visitedStates[startMap]) == 0 {
+		t.Errorf("no states kept for %s", startMap)
+	}
+	if len(mapExits) == 0 {
+		t.Fatalf("no exits loaded for %s", introMap)
+	}
+
+	// Standing in an exit right after arriving doesn't leave.
+	exit := mapExits[0]
+	vars.Player.SetPosition(exit.rect.X, exit.rect.Y)
+	if taken := exitToTake(1.0 / 60); taken != nil {
+		t.Errorf("exit to %s taken right after arriving", taken.path)
+	}
+	if taken := exitToTake(exitGraceSeconds); taken == nil || taken.path != startMap {
+		t.Errorf("exit taken after the grace = %v, want the one to %s", taken, startMap)
+	}
+
+	core.Get[*stats.Comp](vars.Player).ApplyEffect(stats.Dread)
+	h.Input = &utils.InputScript{}
+	h.Input.Hold(h.Frame, h.Frame+400, utils.KeyLeft)
+	runUntilMap(t, h, startMap, 400)
+	x, y = vars.Player.Position()
+	if sx, sy, _ := spawnPoint("from_intro"); x != sx || y != sy {
+		t.Errorf("player back at %v,%v, want on the from_intro spawn %v,%v", x, y, sx, sy)
+	}
+	if stacks := core.Get[*stats.Comp](vars.Player).EffectStacks(stats.Dread); stacks != 1 {
+		t.Errorf("%d dread stacks after travelling, want the one applied before", stacks)
+	}
+	if _, ok := visitedStates[introMap]; !ok {
+		t.Errorf("no states kept for %s", introMap)
+	}
+	if chest, ok := vars.World.Get(chestID).(*entity.Chest); !ok || !chest.Opened() {
+		t.Errorf("chest %d not opened back on %s", chestID, startMap)
+	}
+
+	if err := Save(); err != nil {
+		t.Fatal(err)
+	}
+	saveData, err := LoadSave()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saveData.PlayerData.Map != startMap || len(saveData.States[startMap]) == 0 {
+		t.Errorf("save on %s with %d states for it, want %s with some", saveData.PlayerData.Map,
+			len(saveData.States[startMap]), startMap)
+	}
+}
@@ -6,25 +6,28 @@ import (
 	"game/comps/ai"
 	"game/comps/anim"
 	"game/comps/body"
+	"game/comps/boss"
 	"game/comps/hitbox"
 	"game/comps/stats"
 	"game/core"
+	"game/enemies"
 	"game/entity"
-	"game/entity/actor"
-	"game/maps"
 	"game/shader"
 	"game/utils"
 	"game/vars"
 	"image/color"
 	"log"
+	"os"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 const (
-	playerID = 25
-	torchGID = 378
+	playerID   = 25
+	torchGID   = 378
+	enemiesDir = "enemies"
+	startMap   = "intro/playground_imp.tmx"
 )
 
 /*
@@ -50,13 +53,13 @@ var (
 	pixelScreen     = ebiten.NewImage(vars.ScreenWidth, vars.ScreenHeight)
 	pipeline        = core.NewPipeline()
 	entityBinds     = map[uint32]core.EntityContructor{
-		26: toEntityContructor(entity.NewKnight),
-		27: toEntityContructor(entity.NewGhoul),
-		28: toEntityContructor(entity.NewSkeleman),
-		29: toEntityContructor(entity.NewCrawler),
-		30: toEntityContructor(entity.NewRat),
-		31: toEntityContructor(entity.NewBat),
-		32: toEntityContructor(entity.NewEnt),
+		26: namedEntity("Knight"),
+		27: namedEntity("Ghoul"),
+		28: namedEntity("Skeleman"),
+		29: namedEntity("Crawler"),
+		30: namedEntity("Rat"),
+		31: namedEntity("Bat"),
+		32: namedEntity("Ent"),
 		87: toEntityContructor(entity.NewGram),
 		88: toEntityContructor(entity.NewFerragus),
 		89: toEntityContructor(entity.NewOscar),
@@ -68,27 +71,48 @@ var (
 		152: toEntityContructor(entity.NewSpike),
 		153: toEntityContructor(entity.NewFakeWall),
 	}
-	restartTransition, deathTransition Transition
 )
 
-type Transition interface {
-	Init()
-	Update(dt float64) bool
-	Draw(screen *ebiten.Image)
-}
-
 func toEntityContructor[T core.Entity](contructor func(x, y, w, h float64, p *core.Properties) T) core.EntityContructor {
 	return func(x, y, w, h float64, p *core.Properties) core.Entity { return contructor(x, y, w, h, p) }
 }
 
+// namedEntity binds a tile to an entity registered by name, like the ones in the enemies folder. The tile is skipped
+// when nothing has that name, say an enemies folder that renamed it.
+func namedEntity(name string) core.EntityContructor {
+	return func(x, y, w, h float64, p *core.Properties) core.Entity {
+		construct := core.EntityByName(name)
+		if construct == nil {
+			log.Printf("game: no entity named %s, skipping\n", name)
+
+			return nil
+		}
+
+		return construct(x, y, w, h, p)
+	}
+}
+
+// loadEnemies registers the embedded enemy definitions, overridden by an enemies folder next to the game if any.
+func loadEnemies() {
+	if err := entity.LoadEnemies(enemies.FS); err != nil {
+		log.Panicln("error loading enemies:", err)
+	}
+	if _, err := os.Stat(enemiesDir); err != nil {
+		return
+	}
+	if err := entity.LoadEnemies(os.DirFS(enemiesDir)); err != nil {
+		log.Println("game: error loading enemies from disk:", err)
+	}
+}
+
 func Load() {
-	actor.DieParticle = func(e core.Entity) core.Entity { return entity.NewFlake(e) }
-	//worldMap := core.NewMap("intro/intro.tmx", 1, maps.IntroFS, vars.PipelineScreenTag, vars.PipelineNormalMapTag)
-	worldMap := core.NewMap("intro/playground_imp.tmx", 1, maps.IntroFS, vars.PipelineScreenTag, vars.PipelineNormalMapTag)
+	loadEnemies()
+	loadAudio(nil)
+	shader.Load()
 	vars.World = core.NewWorld(float64(vars.ScreenWidth), float64(vars.ScreenHeight))
-	vars.World.SetMap(worldMap, "rooms")
-	worldMap.LoadBumpObjects(vars.World.Space, "collisions")
-	shader.Load(worldMap, []uint32{torchGID, 931, 993})
+	vars.World.Particles.ImageTag, vars.World.Particles.NormalMapTag = vars.PipelineScreenTag, vars.PipelineNormalMapTag
+	subscribeWorld(vars.World.Events)
+	mapPath = ""
 	Reset()
 }
 
@@ -98,77 +122,46 @@ func Reset() {
 		log.Panicln("error loading save:", err)
 	}
 
+	clear(visitedStates)
 	vars.World.Speed = 1
-	vars.World.RemoveAll()
-	vars.World.Map.LoadEntityObjects(vars.World, "entities", entityBinds)
-	LoadMapEvents(vars.World.Map)
-	vars.World.Update(0)
+	if saveData.PlayerData.Map != mapPath {
+		loadMap(saveData.PlayerData.Map)
+	} else {
+		vars.World.RemoveAll()
+	}
+	loadMapEntities(saveData)
 	ApplySaveData(saveData)
 	vars.World.Add(vars.Player)
 	vars.World.Camera.Follow(vars.Player)
 	vars.World.Update(0)
 }
 
-type Game struct{ loaded bool }
+// Game runs the SceneStack, starting on the title screen or right into a save slot.
+type Game struct {
+	// Slot is the save slot to play, the title screen is shown when it's 0.
+	Slot   int
+	scenes SceneStack
+}
 
 func (g *Game) Update() error {
-	if !g.loaded {
-		g.loaded = true
-		Load()
-	}
 	dt := 1.0 / 60
-	vars.World.Update(dt)
-	shader.Update(dt)
-	if vars.SaveGame {
-		vars.SaveGame = false
-		if err := Save(); err != nil {
-			return err
-		}
-	}
-	if restartTransition == nil && vars.ResetGame {
-		restartTransition = &RestartTransition{}
-		restartTransition.Init()
-	}
-	if restartTransition != nil {
-		vars.ResetGame = false
-		if done := restartTransition.Update(dt); done {
-			restartTransition = nil
-		}
-	}
-
-	if deathTransition == nil && core.Get[*stats.Comp](vars.Player).Health <= 0 {
-		deathTransition = &DeathTransition{}
-		deathTransition.Init()
-	}
-	if deathTransition != nil {
-		if done := deathTransition.Update(dt); done {
-			deathTransition = nil
-		}
-	}
-
-	if vars.Debug {
-		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
-			return ebiten.Termination
+	if g.scenes.Len() == 0 {
+		loadAudio(nil)
+		if g.Slot == 0 {
+			g.scenes.Push(NewTitleScene(&g.scenes))
+		} else {
+			g.scenes.Push(NewGameScene(&g.scenes, g.Slot))
 		}
-		debugControls()
 	}
+	vars.Controls.Update(vars.Pad)
+	vars.Audio.Update(dt)
 
-	return nil
+	return g.scenes.Update(dt)
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
 	pixelScreen.Fill(backgroundColor)
-	vars.World.Draw(pipeline)
-	pipeline.Compose(vars.PipelineScreenTag, pixelScreen)
-	shader.DrawLights(pipeline, pixelScreen)
-	pipeline.DisposeAll()
-
-	if restartTransition != nil {
-		restartTransition.Draw(pixelScreen)
-	}
-	if deathTransition != nil {
-		deathTransition.Draw(pixelScreen)
-	}
+	g.scenes.Draw(pixelScreen)
 
 	op := &ebiten.DrawImageOptions{}
 	fps := fmt.Sprintf("%0.2f", ebiten.ActualFPS())
@@ -182,6 +175,62 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	shader.DrawPhosphore(pipeline, screen)
 }
 
+// GameScene plays a save slot, opening the pause, rest, death and restart scenes over itself.
+type GameScene struct {
+	scenes *SceneStack
+	slot   int
+}
+
+func NewGameScene(scenes *SceneStack, slot int) *GameScene {
+	return &GameScene{scenes: scenes, slot: slot}
+}
+
+func (s *GameScene) Init() {
+	SelectSlot(s.slot)
+	Load()
+	core.Subscribe(vars.World.Events, func(core.RestAtGrave) { s.scenes.Push(&LevelMenu{}) })
+	core.Subscribe(vars.World.Events, func(restEnded) {
+		if err := Save(); err != nil {
+			s.scenes.Fail(err)
+		}
+		s.scenes.Push(&RestartTransition{})
+	})
+}
+
+func (s *GameScene) Update(dt float64) bool {
+	// The death and restart transitions let the game run under them, without opening anything else.
+	top := s.scenes.Top() == s
+	if top && vars.Controls.KeyPressed(utils.KeyMenu) {
+		s.scenes.Push(&PauseMenu{scenes: s.scenes})
+
+		return false
+	}
+	vars.World.Update(dt)
+	vars.Controls.TickBuffers(vars.World.Elapsed())
+	if exit := exitToTake(dt); top && exit != nil {
+		next := *exit
+		s.scenes.Push(&FadeTransition{swap: func() { travel(next) }})
+	}
+	shader.Update(dt)
+	if top && core.Get[*stats.Comp](vars.Player).Health <= 0 {
+		s.scenes.Push(&DeathTransition{})
+	}
+
+	if vars.Debug {
+		debugControls()
+	}
+
+	return false
+}
+
+func (s *GameScene) Draw(screen *ebiten.Image) {
+	vars.World.Draw(pipeline)
+	boss.DrawBar(pipeline)
+	pipeline.Compose(vars.PipelineScreenTag, screen)
+	shader.DrawLights(pipeline, screen)
+	pipeline.DisposeAll()
+}
+
 func (g *Game) Layout(_, _ int) (int, int) {
 	return vars.ScreenWidth * vars.Scale, vars.ScreenHeight * vars.Scale
 }
@@ -203,9 +252,11 @@ func debugControls() {
 		anim.DebugDraw = !anim.DebugDraw
 	}
 	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
-		shader.Lights = !shader.Lights
+		config.Lights = !config.Lights
+		applyShaderConfig()
 	}
 	if inpututil.IsKeyJustPressed(ebiten.KeyO) {
-		shader.Phosphore = !shader.Phosphore
+		config.Phosphore = !config.Phosphore
+		applyShaderConfig()
 	}
 }
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"encoding/json"
+	"game/core"
+	"game/libs/sound"
+	"game/utils"
+	"game/vars"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Headless steps a Game without a window, one fixed dt per frame, reading input from a script.
+type Headless struct {
+	Game   *Game
+	Input  *utils.InputScript
+	Screen *ebiten.Image
+	Frame  int
+	Sounds *sound.NullSink
+	hooks  map[int][]func(world *core.World)
+}
+
+// NewHeadless seeds every random roll with seed and plays save, a new one when nil, kept in memory instead of the save
+// files. When draw is set each frame is rendered to Screen.
+func NewHeadless(seed uint64, draw bool, save *SaveData) *Headless {
+	utils.Seed(seed)
+	memoryFiles = map[string][]byte{}
+	if save != nil {
+		data, err := json.Marshal(save)
+		if err != nil {
+			log.Panicln("error encoding headless save:", err)
+		}
+		memoryFiles[SlotPath(1)] = data
+	}
+	SelectSlot(1)
+	// No key held or buffered by an earlier run.
+	vars.Controls = utils.NewControls()
+
+	h := &Headless{
+		Game: &Game{Slot: 1}, Input: &utils.InputScript{}, Sounds: &sound.NullSink{},
+		hooks: map[int][]func(world *core.World){},
+	}
+	loadAudio(h.Sounds)
+	if draw {
+		h.Screen = ebiten.NewImage(h.Game.Layout(0, 0))
+	}
+
+	return h
+}
+
+// At runs hook with the world right after the update of the given frame.
+func (h *Headless) At(frame int, hook func(world *core.World)) {
+	h.hooks[frame] = append(h.hooks[frame], hook)
+}
+
+func (h *Headless) Step() error {
+	utils.Input = h.Input
+	defer func() { utils.Input = nil }()
+
+	if err := h.Game.Update(); err != nil {
+		return err
+	}
+	if h.Screen != nil {
+		h.Screen.Clear()
+		h.Game.Draw(h.Screen)
+	}
+	for _, hook := range h.hooks[h.Frame] {
+		hook(vars.World)
+	}
+	h.Frame++
+
+	return nil
+}
+
+func (h *Headless) Run(frames int) error {
+	for range frames {
+		if err := h.Step(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package game
+
+import (
+	"fmt"
+	"game/assets"
+	"game/comps/inventory"
+	"game/core"
+	"game/utils"
+	"game/vars"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const itemsDescriptionLines = 2
+
+// ItemsMenu lists the player inventory, selecting a consumable uses it.
+type ItemsMenu struct {
+	Menu
+	inventory *inventory.Comp
+	owned     []*inventory.Item
+}
+
+func (m *ItemsMenu) Init() {
+	m.Title = "Items"
+	m.inventory = core.Get[*inventory.Comp](vars.Player)
+	m.refresh()
+}
+
+func (m *ItemsMenu) refresh() {
+	m.owned = m.inventory.Owned()
+	m.Items = nil
+	for _, item := range m.owned {
+		m.Items = append(m.Items, MenuItem{
+			Label: func() string { return fmt.Sprintf("%-12s%-4s%s", item.Name, m.countLabel(item), item.Kind) },
+			Select: func() {
+				if m.inventory.Use(item.ID) {
+					m.refresh()
+				}
+			},
+		})
+	}
+	m.Items = append(m.Items, MenuItem{Label: staticLabel("Back"), Select: m.Close})
+	m.cursor = min(m.cursor, len(m.Items)-1)
+}
+
+func (m *ItemsMenu) countLabel(item *inventory.Item) string {
+	if item.Kind == inventory.Charm {
+		return ""
+	}
+
+	return fmt.Sprintf("x%d", m.inventory.Count(item.ID))
+}
+
+func (m *ItemsMenu) Draw(screen *ebiten.Image) {
+	m.Menu.Draw(screen)
+	if m.cursor >= len(m.owned) {
+		return
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(4, float64(vars.ScreenHeight-2-menuLineHeight*itemsDescriptionLines))
+	op.ColorScale.ScaleWithColor(textColor)
+	utils.DrawText(screen, m.owned[m.cursor].Description, assets.NanoFont, op)
+}
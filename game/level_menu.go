@@ -0,0 +1,58 @@
+package game
+
+import (
+	"fmt"
+	"game/comps/stats"
+	"game/core"
+	"game/entity"
+	"game/vars"
+	"math"
+)
+
+const levelBaseCost, levelCostGrowth = 20, 1.2
+
+var playerLevels entity.PlayerLevels
+
+// LevelMenu spends Exp on PlayerLevels while resting, the game is saved and reset when it closes.
+type LevelMenu struct {
+	Menu
+}
+
+func (m *LevelMenu) Init() {
+	m.Items = []MenuItem{
+		m.levelItem("Health", &playerLevels.Health),
+		m.levelItem("Stamina", &playerLevels.Stamina),
+		m.levelItem("Poise", &playerLevels.Poise),
+		m.levelItem("Attack", &playerLevels.Attack),
+		m.levelItem("Heals", &playerLevels.Heal),
+		{Label: staticLabel("Leave"), Select: m.Close},
+	}
+}
+
+func (m *LevelMenu) Update(dt float64) bool {
+	m.Title = fmt.Sprintf("Rest  Exp %d", core.Get[*stats.Comp](vars.Player).Exp)
+	if done := m.Menu.Update(dt); !done {
+		return false
+	}
+	core.Publish(vars.World.Events, restEnded{})
+
+	return true
+}
+
+func (m *LevelMenu) levelItem(name string, level *int) MenuItem {
+	return MenuItem{
+		Label: func() string { return fmt.Sprintf("%-8sLv %-3dCost %d", name, *level, LevelCost(playerLevels)) },
+		Select: func() {
+			playerStats := core.Get[*stats.Comp](vars.Player)
+			if cost := LevelCost(playerLevels); playerStats.Exp >= cost {
+				playerStats.Exp -= cost
+				*level++
+			}
+		},
+	}
+}
+
+// LevelCost is the Exp needed for the next level, rising with every level already bought.
+func LevelCost(levels entity.PlayerLevels) int {
+	return int(levelBaseCost * math.Pow(levelCostGrowth, float64(levels.Total())))
+}
@@ -0,0 +1,57 @@
+package game
+
+import (
+	"game/comps/stats"
+	"game/core"
+	"game/entity"
+	"game/vars"
+	"testing"
+)
+
+func TestLevelCost(t *testing.T) {
+	tests := []struct {
+		levels entity.PlayerLevels
+		want   int
+	}{
+		{entity.PlayerLevels{}, 20},
+		{entity.PlayerLevels{Health: 1}, 24},
+		{entity.PlayerLevels{Stamina: 1, Attack: 1}, 28},
+		{entity.PlayerLevels{Health: 2, Poise: 2, Heal: 1}, 49},
+	}
+	for _, test := range tests {
+		if cost := LevelCost(test.levels); cost != test.want {
+			t.Errorf("cost at %+v = %d, want %d", test.levels, cost, test.want)
+		}
+	}
+}
+
+func TestLevelUp(t *testing.T) {
+	defer func() { playerLevels = entity.PlayerLevels{} }()
+	playerLevels = entity.PlayerLevels{}
+	vars.Player = entity.NewPlayer(0, 0, playerLevels)
+	playerStats := core.Get[*stats.Comp](vars.Player)
+	playerStats.Exp = 50
+	maxHealth := playerStats.MaxHealth
+
+	menu := &LevelMenu{}
+	menu.Init()
+	health := menu.Items[0]
+	health.Select()
+	if playerLevels.Health != 1 || playerStats.Exp != 30 {
+		t.Fatalf("health level %d with %d Exp left, want 1 with 30", playerLevels.Health, playerStats.Exp)
+	}
+	health.Select()
+	if playerLevels.Health != 2 || playerStats.Exp != 6 {
+		t.Fatalf("health level %d with %d Exp left, want 2 with 6", playerLevels.Health, playerStats.Exp)
+	}
+	health.Select()
+	if playerLevels.Health != 2 || playerStats.Exp != 6 {
+		t.Errorf("health level %d with %d Exp left, want the level refused for lack of Exp", playerLevels.Health, playerStats.Exp)
+	}
+
+	// The levels apply to the player built on the reset.
+	leveled := core.Get[*stats.Comp](entity.NewPlayer(0, 0, playerLevels))
+	if leveled.MaxHealth <= maxHealth {
+		t.Errorf("max health %v after two levels, want more than %v", leveled.MaxHealth, maxHealth)
+	}
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"game/assets"
+	"game/utils"
+	"game/vars"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	menuLineHeight   = 7
+	menuVisibleLines = 11
+	menuOverlayAlpha = 0.8
+)
+
+type MenuItem struct {
+	Label  func() string
+	Select func()
+	Adjust func(dir int) // Called with -1 or 1 on Left and Right, for items holding a value.
+}
+
+// Menu is a vertical list of items, moved with Up and Down, selected with Jump, adjusted with Left and Right and left
+// with Guard or Menu.
+type Menu struct {
+	Title   string
+	Items   []MenuItem
+	Modal   bool    // Modal menus can only be closed by their items.
+	OffsetY float64 // Moves the whole menu down, leaving room over it.
+	cursor  int
+	closed  bool
+}
+
+func (m *Menu) Init()          {}
+func (m *Menu) Blocking() bool { return true }
+
+func (m *Menu) Close() { m.closed = true }
+
+func (m *Menu) Update(_ float64) bool {
+	if m.closed {
+		return true
+	}
+	switch {
+	case vars.Controls.KeyPressed(utils.KeyUp):
+		m.cursor = (m.cursor - 1 + len(m.Items)) % len(m.Items)
+	case vars.Controls.KeyPressed(utils.KeyDown):
+		m.cursor = (m.cursor + 1) % len(m.Items)
+	case vars.Controls.KeyPressed(utils.KeyLeft) && m.Items[m.cursor].Adjust != nil:
+		m.Items[m.cursor].Adjust(-1)
+	case vars.Controls.KeyPressed(utils.KeyRight) && m.Items[m.cursor].Adjust != nil:
+		m.Items[m.cursor].Adjust(1)
+	case vars.Controls.KeyPressed(utils.KeyJump):
+		if selectItem := m.Items[m.cursor].Select; selectItem != nil {
+			selectItem()
+		}
+	case !m.Modal && (vars.Controls.KeyPressed(utils.KeyGuard) || vars.Controls.KeyPressed(utils.KeyMenu)):
+		m.Close()
+	}
+
+	return m.closed
+}
+
+func (m *Menu) Draw(screen *ebiten.Image) {
+	op := &ebiten.DrawImageOptions{}
+	op.ColorScale.ScaleAlpha(menuOverlayAlpha)
+	screen.DrawImage(fadeImg, op)
+
+	op = &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(4, 2+m.OffsetY)
+	op.ColorScale.ScaleWithColor(textColor)
+	utils.DrawText(screen, m.Title, assets.NanoFont, op)
+
+	first := max(0, min(m.cursor-menuVisibleLines/2, len(m.Items)-menuVisibleLines))
+	for i := first; i < min(len(m.Items), first+menuVisibleLines); i++ {
+		label := "  " + m.Items[i].Label()
+		if i == m.cursor {
+			label = "> " + m.Items[i].Label()
+		}
+		op.GeoM.Reset()
+		op.GeoM.Translate(4, 2+m.OffsetY+float64(menuLineHeight*(i-first+1)))
+		utils.DrawText(screen, label, assets.NanoFont, op)
+	}
+}
+
+func staticLabel(label string) func() string { return func() string { return label } }
@@ -0,0 +1,49 @@
+package game
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// PauseMenu is opened with the Menu key, leading to every other in-game screen.
+type PauseMenu struct {
+	Menu
+	scenes *SceneStack
+	screen Scene
+}
+
+func (m *PauseMenu) Init() {
+	m.Title = "Paused"
+	m.Items = []MenuItem{
+		{Label: staticLabel("Resume"), Select: m.Close},
+		{Label: staticLabel("Items"), Select: func() { m.open(&ItemsMenu{}) }},
+		{Label: staticLabel("Settings"), Select: func() { m.open(&SettingsMenu{}) }},
+		{Label: staticLabel("Controls"), Select: func() { m.open(&ControlsMenu{}) }},
+		{Label: staticLabel("Quit to Title"), Select: func() {
+			m.Close()
+			m.scenes.Switch(NewTitleScene(m.scenes))
+		}},
+	}
+}
+
+func (m *PauseMenu) Update(dt float64) bool {
+	if m.screen == nil {
+		return m.Menu.Update(dt)
+	}
+	if done := m.screen.Update(dt); done {
+		m.screen = nil
+	}
+
+	return false
+}
+
+func (m *PauseMenu) Draw(screen *ebiten.Image) {
+	if m.screen != nil {
+		m.screen.Draw(screen)
+
+		return
+	}
+	m.Menu.Draw(screen)
+}
+
+func (m *PauseMenu) open(screen Scene) {
+	m.screen = screen
+	m.screen.Init()
+}
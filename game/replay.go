@@ -0,0 +1,98 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"game/utils"
+	"math/rand/v2"
+	"os"
+)
+
+// recordingHeader opens a recording with what the session started from besides its input: the slot given to play
+// and the save slots and config files, by path, so a replay on another machine plays from the same ones.
+type recordingHeader struct {
+	Slot  int               `json:"slot"`
+	Files map[string][]byte `json:"files"`
+}
+
+// Record seeds the game and writes the save files and every frame input to path until the returned stop is called.
+func Record(path string, slot int) (stop func() error, err error) {
+	header := recordingHeader{Slot: slot, Files: map[string][]byte{}}
+	if data, err := readFile(configPath); err == nil {
+		header.Files[configPath] = data
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	for saved := 1; saved <= SaveSlots; saved++ {
+		if data, err := readSlot(saved); err == nil {
+			header.Files[SlotPath(saved)] = data
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	headerData, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(file, "%s\n", headerData); err != nil {
+		file.Close()
+
+		return nil, err
+	}
+	seed := rand.Uint64() //nolint: gosec
+	if utils.Recorder, err = utils.NewInputRecorder(file, seed); err != nil {
+		file.Close()
+
+		return nil, err
+	}
+	utils.Seed(seed)
+
+	return func() error {
+		defer file.Close()
+		if utils.Recorder == nil {
+			return nil
+		}
+		defer func() { utils.Recorder = nil }()
+
+		return utils.Recorder.Close()
+	}, nil
+}
+
+// Replay feeds the input recorded in path back to the game, with the same seed it was played with. The save slots and
+// config are the recorded ones, kept in memory, and the slot it was played on is returned.
+func Replay(path string) (slot int, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	headerData, err := reader.ReadBytes('\n')
+	if err != nil {
+		return 0, fmt.Errorf("replay: bad recording header: %w", err)
+	}
+	var header recordingHeader
+	if err := json.Unmarshal(headerData, &header); err != nil {
+		return 0, fmt.Errorf("replay: bad recording header: %w", err)
+	}
+	script, seed, err := utils.ReadInputScript(reader)
+	if err != nil {
+		return 0, err
+	}
+	if header.Files == nil {
+		header.Files = map[string][]byte{}
+	}
+	memoryFiles = header.Files
+	SelectSlot(1)
+	utils.Seed(seed)
+	utils.Input = script
+
+	return header.Slot, nil
+}
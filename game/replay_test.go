@@ -0,0 +1,49 @@
+package game
+
+import (
+	"game/utils"
+	"os"
+	"testing"
+)
+
+func TestReplayRecordedFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+	memoryFiles = nil
+	defer func() { memoryFiles, utils.Input, config = nil, nil, DefaultConfig() }()
+	defer SelectSlot(1)
+	if err := os.WriteFile(SlotPath(2), []byte(`{"version": 3, "player_data": {"exp": 12}}`), fileMode); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte(`{"master": 0.5}`), fileMode); err != nil {
+		t.Fatal(err)
+	}
+	stop, err := Record("session.rec", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The replaying machine has its own files.
+	if err := os.WriteFile(SlotPath(2), []byte(`{"version": 3, "player_data": {"exp": 99}}`), fileMode); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(configPath); err != nil {
+		t.Fatal(err)
+	}
+	slot, err := Replay("session.rec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slot != 2 {
+		t.Errorf("replay slot = %d, want the recorded 2", slot)
+	}
+	if err := LoadConfig(); err != nil || config.Master != 0.5 {
+		t.Errorf("replay master volume = %v, %v, want the recorded 0.5", config.Master, err)
+	}
+	SelectSlot(slot)
+	if saveData, err := LoadSave(); err != nil || saveData.PlayerData.Exp != 12 {
+		t.Errorf("replay save = %+v, %v, want the recorded one with 12 Exp", saveData, err)
+	}
+}
@@ -20,14 +20,13 @@ type RestartTransition struct {
 	fadeTween *gween.Tween
 }
 
+func (t *RestartTransition) Blocking() bool { return false }
+
 func (t *RestartTransition) Init() {
 	t.fadeTween = gween.New(0, 1, 3, ease.OutQuad)
 }
 
 func (t *RestartTransition) Update(dt float64) bool {
-	if t == nil {
-		return false
-	}
 	if _, done := t.fadeTween.Update(float32(dt)); done {
 		Reset()
 
@@ -38,9 +37,6 @@ func (t *RestartTransition) Update(dt float64) bool {
 }
 
 func (t *RestartTransition) Draw(screen *ebiten.Image) {
-	if t == nil {
-		return
-	}
 	alpha, _ := t.fadeTween.Update(0)
 	op := &ebiten.DrawImageOptions{}
 	op.ColorScale.ScaleAlpha(alpha)
@@ -4,68 +4,110 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"game/comps/inventory"
 	"game/comps/stats"
 	"game/core"
 	"game/entity"
 	"game/utils"
 	"game/vars"
-	"io"
+	"io/fs"
 	"log"
+	"maps"
 	"os"
+	"slices"
 	"syscall"
+	"time"
 )
 
 const (
-	Persistent = false
-	SavePath   = "save.json"
-	fileMode   = 0666
+	Persistent     = false
+	SaveSlots      = 3
+	SaveVersion    = 3
+	legacySavePath = "save.json"
+	fileMode       = 0666
 )
 
-var saveDataCache []byte
+var (
+	saveDataCache []byte
+	saveSlot      = 1
+	// memoryFiles stands in for the save slot and config files when set, by path, like in headless runs.
+	memoryFiles map[string][]byte
+	// migrations[v] upgrades a version v save to v+1.
+	migrations = [SaveVersion]func(save map[string]json.RawMessage) error{
+		0: migrateGamepadBindings,
+		1: migrateOpenedToStates,
+		2: migrateMapStates,
+	}
+)
 
-type Opener interface {
-	Open()
-	Opened() bool
+// PlayerData places the player at the Spawn point of Map when set, like on new saves, or at X, Y.
+type PlayerData struct {
+	Map    string              `json:"map"`
+	Spawn  string              `json:"spawn,omitempty"`
+	X      float64             `json:"x"`
+	Y      float64             `json:"y"`
+	Exp    int                 `json:"exp"`
+	Levels entity.PlayerLevels `json:"levels"`
+	Items  map[string]int      `json:"items,omitempty"`
 }
 
-type PlayerData struct {
-	X   float64 `json:"x"`
-	Y   float64 `json:"y"`
-	Exp int     `json:"exp"`
+// NewerSaveError is returned for a save written by a newer build, which is left untouched instead of being replaced.
+type NewerSaveError struct {
+	Version int
+}
+
+func (e *NewerSaveError) Error() string {
+	return fmt.Sprintf("save version %d is newer than %d", e.Version, SaveVersion)
+}
+
+// MigrationError is returned when an older save can't be upgraded, the save being left untouched too.
+type MigrationError struct {
+	Version int
+	Err     error
+}
+
+func (e *MigrationError) Error() string {
+	return fmt.Sprintf("migrating save from version %d: %v", e.Version, e.Err)
 }
 
+func (e *MigrationError) Unwrap() error { return e.Err }
+
 type SaveData struct {
-	PlayerData PlayerData        `json:"player_data"`
-	Pad        utils.ControlPack `json:"keys"`
-	Opened     []uint            `json:"opened"`
+	Version    int                                  `json:"version"`
+	PlayerData PlayerData                           `json:"player_data"`
+	Pad        utils.ControlPack                    `json:"keys"`
+	States     map[string]map[uint]core.EntityState `json:"states"`
 }
 
 func NewSaveData() *SaveData {
-	obj, err := vars.World.Map.FindObjectFromTileID(playerID, "entities")
-	if err != nil {
-		log.Println("game: error finding player entity:", err)
-	}
-
 	return &SaveData{
-		PlayerData: PlayerData{X: obj.X, Y: obj.Y},
+		Version:    SaveVersion,
+		PlayerData: PlayerData{Map: startMap, Spawn: startSpawn},
 		Pad:        utils.NewControlPack(),
 	}
 }
 
-func Save() error {
-	var saveData *SaveData
-	var err error
-	if len(saveDataCache) != 0 {
-		if err := json.Unmarshal(saveDataCache, &saveData); err != nil {
-			return err
-		}
-	} else {
-		if saveData, err = LoadSave(); err != nil && !os.IsNotExist(err) {
-			return err
-		}
+func Save() error { return updateSave(populateSaveData) }
+
+// SavePad writes the current controls to the save, leaving the rest of it untouched.
+func SavePad() error {
+	return updateSave(func(sd *SaveData) error {
+		sd.Pad = vars.Pad
+
+		return nil
+	})
+}
+
+func updateSave(update func(sd *SaveData) error) error {
+	saveData, err := LoadSave()
+	if err != nil {
+		return err
 	}
 
-	populateSaveData(saveData)
+	if err := update(saveData); err != nil {
+		return err
+	}
 
 	if saveDataCache, err = json.Marshal(saveData); err != nil {
 		return err
@@ -81,22 +123,12 @@ func Save() error {
 		return nil
 	}
 
-	saveFile, err := os.OpenFile(SavePath, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, fileMode) //nolint: nosnakecase
-	if err != nil {
-		return err
-	}
-	defer saveFile.Close()
-
-	if _, err := saveFile.Write(saveDataCache); err != nil {
-		return err
-	}
-
-	return nil
+	return writeSlot(saveSlot, saveDataCache)
 }
 
 func LoadSave() (*SaveData, error) {
 	if len(saveDataCache) == 0 {
-		saveFile, err := os.Open(SavePath)
+		data, err := readSlot(saveSlot)
 		if err != nil {
 			if os.IsNotExist(err) || (!Persistent && errors.Is(err, syscall.ENOSYS)) {
 				return NewSaveData(), nil
@@ -104,55 +136,249 @@ func LoadSave() (*SaveData, error) {
 
 			return nil, err
 		}
-		defer saveFile.Close()
+		saveDataCache = data
+	}
+
+	saveData, err := decodeSave(saveDataCache)
+	if refusedSave(err) {
+		saveDataCache = nil
 
-		if saveDataCache, err = io.ReadAll(saveFile); err != nil {
+		return nil, fmt.Errorf("save slot %d: %w", saveSlot, err)
+	}
+	if err != nil {
+		log.Printf("game: save slot %d is corrupt, starting a new one: %v", saveSlot, err)
+		saveDataCache = nil
+		if err := backupSlot(saveSlot); err != nil {
 			return nil, err
 		}
+
+		return NewSaveData(), nil
 	}
 
-	var saveData *SaveData
-	if err := json.Unmarshal(saveDataCache, &saveData); err != nil {
+	return saveData, nil
+}
+
+// refusedSave reports whether err is about a save that is fine but can't be loaded by this build, so it must be kept.
+func refusedSave(err error) bool {
+	var newerErr *NewerSaveError
+	var migrationErr *MigrationError
+
+	return errors.As(err, &newerErr) || errors.As(err, &migrationErr)
+}
+
+func decodeSave(data []byte) (*SaveData, error) {
+	var save map[string]json.RawMessage
+	if err := json.Unmarshal(data, &save); err != nil {
 		return nil, err
 	}
+	if save == nil {
+		return nil, errors.New("empty save")
+	}
+
+	version := 0
+	if rawVersion, ok := save["version"]; ok {
+		if err := json.Unmarshal(rawVersion, &version); err != nil {
+			return nil, err
+		}
+	}
+	if version < 0 {
+		return nil, fmt.Errorf("unknown save version %d", version)
+	}
+	if version > SaveVersion {
+		return nil, &NewerSaveError{Version: version}
+	}
+	for ; version < SaveVersion; version++ {
+		if err := migrations[version](save); err != nil {
+			return nil, &MigrationError{Version: version, Err: err}
+		}
+	}
+
+	migrated, err := json.Marshal(save)
+	if err != nil {
+		return nil, err
+	}
+	saveData := &SaveData{}
+	if err := json.Unmarshal(migrated, saveData); err != nil {
+		return nil, err
+	}
+	saveData.Version = SaveVersion
 
 	return saveData, nil
 }
 
+func SlotPath(slot int) string { return fmt.Sprintf("save%d.json", slot) }
+
+// SelectSlot makes every following Save and LoadSave use the given slot.
+func SelectSlot(slot int) { saveSlot, saveDataCache = slot, nil }
+
+func readSlot(slot int) ([]byte, error) {
+	data, err := readFile(SlotPath(slot))
+	if os.IsNotExist(err) && slot == 1 {
+		return readFile(legacySavePath)
+	}
+
+	return data, err
+}
+
+func writeSlot(slot int, data []byte) error { return storeFile(SlotPath(slot), data) }
+
+func readFile(path string) ([]byte, error) {
+	if memoryFiles == nil {
+		return os.ReadFile(path)
+	}
+	data, ok := memoryFiles[path]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: path, Err: fs.ErrNotExist}
+	}
+
+	return data, nil
+}
+
+func storeFile(path string, data []byte) error {
+	if memoryFiles == nil {
+		return writeFile(path, data)
+	}
+	memoryFiles[path] = data
+
+	return nil
+}
+
+// writeFile goes through a temporary file, so a crash mid write never leaves a truncated file behind.
+func writeFile(path string, data []byte) error {
+	tempFile, err := os.OpenFile(path+".tmp", os.O_WRONLY|os.O_TRUNC|os.O_CREATE, fileMode) //nolint: nosnakecase
+	if err != nil {
+		return err
+	}
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+
+		return err
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
+
+func backupSlot(slot int) error {
+	if !Persistent || memoryFiles != nil {
+		return nil
+	}
+	path := SlotPath(slot)
+	if _, err := os.Stat(path); err != nil {
+		path = legacySavePath
+	}
+	err := os.Rename(path, fmt.Sprintf("%s.%d.bak", path, time.Now().Unix()))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+// migrateGamepadBindings gives keyboard only controls the default gamepad bindings and a Menu key.
+func migrateGamepadBindings(save map[string]json.RawMessage) error {
+	var pad utils.ControlPack
+	if keys, ok := save["keys"]; ok {
+		if err := json.Unmarshal(keys, &pad); err != nil {
+			return err
+		}
+	}
+	defaultPad := utils.NewControlPack()
+	for key, bindings := range pad {
+		if len(bindings) == 0 {
+			pad[key] = defaultPad[key]
+
+			continue
+		}
+		if !slices.ContainsFunc(bindings, utils.Binding.Gamepad) {
+			for _, binding := range defaultPad[key] {
+				if binding.Gamepad() {
+					pad[key] = append(pad[key], binding)
+				}
+			}
+		}
+	}
+
+	keys, err := json.Marshal(pad)
+	save["keys"] = keys
+
+	return err
+}
+
 func ApplySaveData(sd *SaveData) {
-	vars.Player = entity.NewPlayer(sd.PlayerData.X, sd.PlayerData.Y)
+	playerLevels = sd.PlayerData.Levels
+	vars.Player = entity.NewPlayer(sd.PlayerData.X, sd.PlayerData.Y, playerLevels)
+	placePlayer(sd.PlayerData.X, sd.PlayerData.Y, sd.PlayerData.Spawn)
 	core.Get[*stats.Comp](vars.Player).Exp = sd.PlayerData.Exp
+	core.Get[*inventory.Comp](vars.Player).Items = maps.Clone(sd.PlayerData.Items)
 	vars.Pad = sd.Pad
-	for _, opened := range sd.Opened {
-		if opener, ok := vars.World.Get(opened).(Opener); ok {
-			opener.Open()
-		}
-	}
 }
 
-func populateSaveData(sd *SaveData) {
+func populateSaveData(sd *SaveData) error {
 	playerStats := core.Get[*stats.Comp](vars.Player)
+	sd.PlayerData.Map, sd.PlayerData.Spawn = mapPath, ""
 	sd.PlayerData.X, sd.PlayerData.Y = vars.Player.Position()
 	sd.PlayerData.Exp = playerStats.Exp
+	sd.PlayerData.Levels = playerLevels
+	sd.PlayerData.Items = maps.Clone(core.Get[*inventory.Comp](vars.Player).Items)
 	sd.Pad = vars.Pad
 
-	for _, e := range vars.World.GetAll() {
-		id := vars.World.GetID(e)
-		if id == 0 {
-			continue
-		}
-		if opener, ok := e.(Opener); ok && opener.Opened() {
-			sd.Opened = append(sd.Opened, id)
-		}
+	states, err := vars.World.SaveStates()
+	if err != nil {
+		return err
 	}
+	if sd.States == nil {
+		sd.States = map[string]map[uint]core.EntityState{}
+	}
+	maps.Copy(sd.States, visitedStates)
+	sd.States[mapPath] = states
 
-	for _, e := range vars.World.GetRemoved() {
-		id := vars.World.GetID(e)
-		if id == 0 {
-			continue
-		}
-		if opener, ok := e.(Opener); ok && opener.Opened() {
-			sd.Opened = append(sd.Opened, id)
+	return nil
+}
+
+// migrateOpenedToStates moves the IDs of opened chests, doors and fake walls into their entity state.
+func migrateOpenedToStates(save map[string]json.RawMessage) error {
+	var opened []uint
+	if rawOpened, ok := save["opened"]; ok {
+		if err := json.Unmarshal(rawOpened, &opened); err != nil {
+			return err
 		}
 	}
+	states := map[uint]core.EntityState{}
+	for _, id := range opened {
+		states[id] = core.EntityState{"entity": json.RawMessage(`{"open":true}`)}
+	}
+	delete(save, "opened")
+
+	rawStates, err := json.Marshal(states)
+	save["states"] = rawStates
+
+	return err
+}
+
+// migrateMapStates puts the entity states and the player on the only map there was.
+func migrateMapStates(save map[string]json.RawMessage) error {
+	if rawStates, ok := save["states"]; ok {
+		save["states"] = json.RawMessage(`{"` + startMap + `":` + string(rawStates) + `}`)
+	}
+	rawPlayer, ok := save["player_data"]
+	if !ok {
+		return nil
+	}
+	var player map[string]json.RawMessage
+	if err := json.Unmarshal(rawPlayer, &player); err != nil || player == nil {
+		return err
+	}
+	player["map"] = json.RawMessage(`"` + startMap + `"`)
+	var err error
+	save["player_data"], err = json.Marshal(player)
+
+	return err
 }
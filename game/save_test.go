@@ -0,0 +1,132 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"game/comps/stats"
+	"game/core"
+	"game/utils"
+	"game/vars"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// legacySave is a save of the first version, with keyboard only keys stored as raw key numbers and the opened entity
+// IDs.
+func legacySave(t *testing.T) []byte {
+	t.Helper()
+	keys := [len(utils.ControlPack{})][]ebiten.Key{
+		utils.KeyRight: {ebiten.KeyArrowRight}, utils.KeyLeft: {ebiten.KeyArrowLeft},
+		utils.KeyJump: {ebiten.KeyZ}, utils.KeyAction: {ebiten.KeyX},
+	}
+	data, err := json.Marshal(map[string]any{
+		"player_data": map[string]any{"x": 10, "y": 20, "exp": 7},
+		"keys":        keys,
+		"opened":      []uint{12, 34},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestDecodeLegacySave(t *testing.T) {
+	saveData, err := decodeSave(legacySave(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saveData.Version != SaveVersion {
+		t.Errorf("version = %d, want %d", saveData.Version, SaveVersion)
+	}
+	if player := saveData.PlayerData; player.Map != startMap || player.X != 10 || player.Exp != 7 {
+		t.Errorf("player data = %+v, want the legacy one on %s", player, startMap)
+	}
+	if !slices.Contains(saveData.Pad[utils.KeyJump], utils.KeyBinding(ebiten.KeyZ)) {
+		t.Errorf("jump bindings = %v, want the legacy key kept", saveData.Pad[utils.KeyJump])
+	}
+	if !slices.ContainsFunc(saveData.Pad[utils.KeyJump], utils.Binding.Gamepad) {
+		t.Errorf("jump bindings = %v, want the default gamepad ones added", saveData.Pad[utils.KeyJump])
+	}
+	for _, id := range []uint{12, 34} {
+		var state struct{ Open bool }
+		if err := json.Unmarshal(saveData.States[startMap][id]["entity"], &state); err != nil || !state.Open {
+			t.Errorf("entity %d state = %s, want open", id, saveData.States[startMap][id]["entity"])
+		}
+	}
+}
+
+func TestDecodeSaveErrors(t *testing.T) {
+	var newerErr *NewerSaveError
+	if _, err := decodeSave([]byte(`{"version": 99}`)); !errors.As(err, &newerErr) || newerErr.Version != 99 {
+		t.Errorf("newer save error = %v, want a NewerSaveError", err)
+	}
+	var migrationErr *MigrationError
+	if _, err := decodeSave([]byte(`{"keys": "up"}`)); !errors.As(err, &migrationErr) || migrationErr.Version != 0 {
+		t.Errorf("bad keys error = %v, want a MigrationError from version 0", err)
+	}
+	for _, data := range []string{`{"version": `, `null`, `{"version": -1}`} {
+		if _, err := decodeSave([]byte(data)); err == nil || refusedSave(err) {
+			t.Errorf("%s error = %v, want it corrupt", data, err)
+		}
+	}
+}
+
+func TestLoadNewerSaveKeepsSlot(t *testing.T) {
+	t.Chdir(t.TempDir())
+	memoryFiles = nil
+	data := []byte(`{"version": 99, "player_data": {"exp": 500}}`)
+	if err := os.WriteFile(SlotPath(1), data, fileMode); err != nil {
+		t.Fatal(err)
+	}
+	SelectSlot(1)
+	defer SelectSlot(1)
+
+	if _, err := LoadSave(); !refusedSave(err) {
+		t.Fatalf("error = %v, want the slot refused", err)
+	}
+	if err := Save(); err == nil {
+		t.Error("saving over a newer slot didn't fail")
+	}
+	if kept, err := os.ReadFile(SlotPath(1)); err != nil || string(kept) != string(data) {
+		t.Errorf("slot = %s, %v, want it untouched", kept, err)
+	}
+	if backups, _ := filepath.Glob("*.bak"); len(backups) > 0 {
+		t.Errorf("backups %v made of a newer slot", backups)
+	}
+}
+
+func TestHeadlessSave(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile(SlotPath(1), []byte(`{"version": 99}`), fileMode); err != nil {
+		t.Fatal(err)
+	}
+	save := NewSaveData()
+	save.PlayerData.Exp = 42
+	h := NewHeadless(1, false, save)
+	if err := h.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if exp := core.Get[*stats.Comp](vars.Player).Exp; exp != 42 {
+		t.Errorf("headless player Exp = %d, want the 42 of the save given instead of the slot on disk", exp)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save1.json")
+	for _, data := range []string{`{"version": 1, "long": "first"}`, `{"version": 2}`} {
+		if err := writeFile(path, []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+		if written, err := os.ReadFile(path); err != nil || string(written) != data {
+			t.Errorf("file = %s, %v, want %s", written, err, data)
+		}
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const fadeSeconds = 0.4
+
+// Scene is one screen of the game, Update returning true once it's done and popped off the SceneStack.
+type Scene interface {
+	Init()
+	Update(dt float64) bool
+	Draw(screen *ebiten.Image)
+}
+
+// Layer scenes are drawn over the scene under them. The scene under keeps updating unless the layer is Blocking,
+// like menus freezing the game while the death and restart transitions play over it.
+type Layer interface {
+	Blocking() bool
+}
+
+// SceneStack updates its top scene and draws from the last full screen scene up.
+type SceneStack struct {
+	scenes []Scene
+	err    error
+}
+
+func (s *SceneStack) Push(scene Scene) {
+	scene.Init()
+	s.scenes = append(s.scenes, scene)
+}
+
+// Switch fades out to the next scene, replacing the whole stack with it.
+func (s *SceneStack) Switch(next Scene) {
+	fade := &FadeTransition{}
+	fade.swap = func() {
+		next.Init()
+		s.scenes = []Scene{next, fade}
+	}
+	s.Push(fade)
+}
+
+// Fail stops the game with err on the next update.
+func (s *SceneStack) Fail(err error) { s.err = err }
+
+func (s *SceneStack) Top() Scene {
+	if len(s.scenes) == 0 {
+		return nil
+	}
+
+	return s.scenes[len(s.scenes)-1]
+}
+
+func (s *SceneStack) Len() int { return len(s.scenes) }
+
+func (s *SceneStack) Update(dt float64) error {
+	updating := len(s.scenes) - 1
+	for updating > 0 {
+		if layer, ok := s.scenes[updating].(Layer); !ok || layer.Blocking() {
+			break
+		}
+		updating--
+	}
+	// Scenes pushed while updating only start on the next frame.
+	for _, scene := range slices.Clone(s.scenes[max(updating, 0):]) {
+		if done := scene.Update(dt); done {
+			s.remove(scene)
+		}
+	}
+
+	return s.err
+}
+
+func (s *SceneStack) Draw(screen *ebiten.Image) {
+	first := len(s.scenes) - 1
+	for first > 0 {
+		if _, ok := s.scenes[first].(Layer); !ok {
+			break
+		}
+		first--
+	}
+	for _, scene := range s.scenes[max(first, 0):] {
+		scene.Draw(screen)
+	}
+}
+
+func (s *SceneStack) remove(scene Scene) {
+	if i := slices.Index(s.scenes, scene); i >= 0 {
+		s.scenes = slices.Delete(s.scenes, i, i+1)
+	}
+}
+
+// FadeTransition fades to black, calls swap while the screen is black, then fades back in.
+type FadeTransition struct {
+	swap     func()
+	timer    float64
+	switched bool
+}
+
+func (t *FadeTransition) Init()          {}
+func (t *FadeTransition) Blocking() bool { return true }
+
+func (t *FadeTransition) Update(dt float64) bool {
+	if t.timer += dt; !t.switched && t.timer >= fadeSeconds {
+		t.switched = true
+		t.swap()
+	}
+
+	return t.timer >= fadeSeconds*2
+}
+
+func (t *FadeTransition) Draw(screen *ebiten.Image) {
+	alpha := t.timer / fadeSeconds
+	if t.switched {
+		alpha = 2 - alpha
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.ColorScale.ScaleAlpha(float32(max(0, min(1, alpha))))
+	screen.DrawImage(fadeImg, op)
+}
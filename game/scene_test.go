@@ -0,0 +1,85 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// countedScene counts its inits, updates and draws, done once told so.
+type countedScene struct {
+	inits, updates, draws int
+	done                  bool
+}
+
+func (s *countedScene) Init()                 { s.inits++ }
+func (s *countedScene) Update(_ float64) bool { s.updates++; return s.done }
+func (s *countedScene) Draw(_ *ebiten.Image)  { s.draws++ }
+
+type countedLayer struct {
+	countedScene
+	blocking bool
+}
+
+func (l *countedLayer) Blocking() bool { return l.blocking }
+
+func TestSceneStack(t *testing.T) {
+	scenes := &SceneStack{}
+	game, menu := &countedScene{}, &countedLayer{blocking: true}
+	scenes.Push(game)
+	scenes.Push(menu)
+	if game.inits != 1 || menu.inits != 1 || scenes.Len() != 2 || scenes.Top() != menu {
+		t.Fatalf("stack of %d topped by %T after two pushes, want both inited with the menu on top", scenes.Len(), scenes.Top())
+	}
+
+	if err := scenes.Update(0.1); err != nil {
+		t.Fatal(err)
+	}
+	if game.updates != 0 || menu.updates != 1 {
+		t.Errorf("updates under a blocking menu = %d game, %d menu, want only the menu", game.updates, menu.updates)
+	}
+	scenes.Draw(nil)
+	if game.draws != 1 || menu.draws != 1 {
+		t.Errorf("draws = %d game, %d menu, want the menu drawn over the game", game.draws, menu.draws)
+	}
+
+	// Done, the menu pops off and the game runs again.
+	menu.done = true
+	if err := scenes.Update(0.1); err != nil {
+		t.Fatal(err)
+	}
+	if scenes.Len() != 1 || scenes.Top() != game {
+		t.Fatalf("stack of %d topped by %T once the menu is done, want only the game", scenes.Len(), scenes.Top())
+	}
+	if err := scenes.Update(0.1); err != nil {
+		t.Fatal(err)
+	}
+	if game.updates != 1 {
+		t.Errorf("game updated %d times once the menu popped, want once", game.updates)
+	}
+}
+
+func TestSceneStackLayers(t *testing.T) {
+	scenes := &SceneStack{}
+	game, transition, credits := &countedScene{}, &countedLayer{}, &countedScene{}
+	scenes.Push(game)
+	scenes.Push(transition)
+	if err := scenes.Update(0.1); err != nil {
+		t.Fatal(err)
+	}
+	if game.updates != 1 || transition.updates != 1 {
+		t.Errorf("updates under a non blocking layer = %d game, %d layer, want both", game.updates, transition.updates)
+	}
+
+	scenes.Push(credits)
+	if err := scenes.Update(0.1); err != nil {
+		t.Fatal(err)
+	}
+	scenes.Draw(nil)
+	if game.updates != 1 || transition.updates != 1 || credits.updates != 1 {
+		t.Errorf("updates = %d game, %d layer, %d credits, want only the top scene", game.updates, transition.updates, credits.updates)
+	}
+	if game.draws != 0 || transition.draws != 0 || credits.draws != 1 {
+		t.Errorf("draws = %d game, %d layer, %d credits, want only the full screen top", game.draws, transition.draws, credits.draws)
+	}
+}
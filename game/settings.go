@@ -0,0 +1,169 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"game/shader"
+	"game/vars"
+	"log"
+	"math"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	configPath = "config.json"
+	volumeStep = 0.1
+	maxScale   = 8
+)
+
+// Config holds the settings of the game itself, shared by every save slot.
+type Config struct {
+	Master     float64 `json:"master"`
+	Music      float64 `json:"music"`
+	SFX        float64 `json:"sfx"`
+	Scale      int     `json:"scale"`
+	Vsync      bool    `json:"vsync"`
+	Fullscreen bool    `json:"fullscreen"`
+	Lights     bool    `json:"lights"`
+	Phosphore  bool    `json:"phosphore"`
+}
+
+var config = DefaultConfig()
+
+func DefaultConfig() Config {
+	return Config{
+		Master: 1, Music: 1, SFX: 1, Scale: vars.Scale,
+		Vsync: !vars.Debug, Lights: !vars.Debug, Phosphore: !vars.Debug,
+	}
+}
+
+// LoadConfig reads the config file over the defaults, keeping them when there's none.
+func LoadConfig() error {
+	config = DefaultConfig()
+	data, err := readFile(configPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		config = DefaultConfig()
+
+		return fmt.Errorf("config: %w", err)
+	}
+	config.Scale = max(1, min(maxScale, config.Scale))
+	for _, volume := range []*float64{&config.Master, &config.Music, &config.SFX} {
+		*volume = max(0, min(1, *volume))
+	}
+
+	return nil
+}
+
+// ApplyConfig sets the window, shaders and volumes from the config.
+func ApplyConfig() {
+	applyWindowSize()
+	applyVsync()
+	applyFullscreen()
+	applyShaderConfig()
+	applyAudioConfig()
+}
+
+func applyWindowSize() {
+	ebiten.SetWindowSize(vars.ScreenWidth*config.Scale, vars.ScreenHeight*config.Scale)
+}
+
+func applyVsync()      { ebiten.SetVsyncEnabled(config.Vsync) }
+func applyFullscreen() { ebiten.SetFullscreen(config.Fullscreen) }
+
+func applyShaderConfig() { shader.Lights, shader.Phosphore = config.Lights, config.Phosphore }
+
+func applyAudioConfig() {
+	if vars.Audio != nil {
+		vars.Audio.Master, vars.Audio.Music, vars.Audio.SFX = config.Master, config.Music, config.SFX
+	}
+}
+
+// saveConfig writes the config whatever Persistent is, that one being about the save slots.
+func saveConfig() {
+	data, err := json.MarshalIndent(config, "", "	")
+	if err == nil {
+		err = storeFile(configPath, data)
+	}
+	if err != nil {
+		log.Println("game: error saving config:", err)
+	}
+}
+
+// SettingsMenu changes the Config, Left and Right or Jump stepping through the values. Each item applies only its own
+// setting, saving the config when it changed.
+type SettingsMenu struct {
+	Menu
+}
+
+func (m *SettingsMenu) Init() {
+	m.Title = "Settings"
+	m.Items = []MenuItem{
+		volumeItem("Master", &config.Master),
+		volumeItem("Music", &config.Music),
+		volumeItem("Sound", &config.SFX),
+		{
+			Label: func() string { return fmt.Sprintf("Scale  %dx", config.Scale) },
+			Adjust: func(dir int) {
+				config.Scale = (config.Scale+dir+maxScale-1)%maxScale + 1
+				applyWindowSize()
+			},
+		},
+		toggleItem("Vsync", &config.Vsync, applyVsync),
+		toggleItem("Fullscreen", &config.Fullscreen, applyFullscreen),
+		toggleItem("Lights", &config.Lights, applyShaderConfig),
+		toggleItem("Phosphore", &config.Phosphore, applyShaderConfig),
+		{Label: staticLabel("Reset Defaults"), Select: func() {
+			config = DefaultConfig()
+			ApplyConfig()
+			saveConfig()
+		}},
+		{Label: staticLabel("Back"), Select: m.Close},
+	}
+	for i, item := range m.Items {
+		if adjust := item.Adjust; adjust != nil {
+			m.Items[i].Adjust = func(dir int) {
+				previous := config
+				if adjust(dir); config != previous {
+					saveConfig()
+				}
+			}
+			m.Items[i].Select = func() { m.Items[i].Adjust(1) }
+		}
+	}
+}
+
+func volumeItem(name string, volume *float64) MenuItem {
+	return MenuItem{
+		Label: func() string { return fmt.Sprintf("%s  %d%%", name, int(math.Round(*volume*100))) }, //nolint: mnd
+		Adjust: func(dir int) {
+			steps := int(math.Round(1 / volumeStep))
+			step := max(0, min(steps, int(math.Round(*volume/volumeStep))+dir))
+			*volume = float64(step) * volumeStep
+			applyAudioConfig()
+		},
+	}
+}
+
+func toggleItem(name string, value *bool, apply func()) MenuItem {
+	return MenuItem{
+		Label: func() string {
+			if *value {
+				return name + "  On"
+			}
+
+			return name + "  Off"
+		},
+		Adjust: func(_ int) {
+			*value = !*value
+			apply()
+		},
+	}
+}
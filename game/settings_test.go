@@ -0,0 +1,52 @@
+package game
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	memoryFiles = nil
+	defer func() { config = DefaultConfig() }()
+
+	if err := LoadConfig(); err != nil || config != DefaultConfig() {
+		t.Errorf("config without a file = %+v, %v, want the defaults", config, err)
+	}
+	data := []byte(`{"master": 3, "music": -1, "scale": 99, "fullscreen": true}`)
+	if err := os.WriteFile(configPath, data, fileMode); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	want := DefaultConfig()
+	want.Master, want.Music, want.Scale, want.Fullscreen = 1, 0, maxScale, true
+	if config != want {
+		t.Errorf("config = %+v, want %+v", config, want)
+	}
+
+	if err := os.WriteFile(configPath, []byte(`{"master": `), fileMode); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadConfig(); err == nil || config != DefaultConfig() {
+		t.Errorf("broken config = %+v, %v, want an error and the defaults", config, err)
+	}
+}
+
+func TestVolumeClamped(t *testing.T) {
+	volume := 0.9
+	item := volumeItem("Test", &volume)
+	for range 3 {
+		item.Adjust(1)
+	}
+	if volume != 1 {
+		t.Errorf("volume raised past the top = %v, want 1", volume)
+	}
+	for range 12 {
+		item.Adjust(-1)
+	}
+	if volume != 0 {
+		t.Errorf("volume lowered past the bottom = %v, want 0", volume)
+	}
+}
@@ -0,0 +1,27 @@
+package game
+
+import (
+	"game/core"
+	"game/entity"
+	"game/vars"
+)
+
+// restEnded is published by the level menu once the player leaves the grave, to save and restart.
+type restEnded struct{}
+
+// subscribeWorld hooks the camera, the music and the exp drops to the events of a new world.
+func subscribeWorld(events *core.Bus) {
+	core.Subscribe(events, func(event core.EntityDamaged) {
+		if event.Entity == vars.Player {
+			vars.World.Camera.Shake(0.5, 1)
+			vars.World.Freeze(0.1)
+		}
+	})
+	core.Subscribe(events, func(event core.HitLanded) {
+		if event.Attacker == vars.Player {
+			vars.World.Camera.Shake(0.1*float32(event.Mult), 0.5*event.Mult)
+		}
+	})
+	core.Subscribe(events, func(event core.EntityDied) { entity.EmitFlakes(event.Entity, event.Exp) })
+	core.Subscribe(events, playRoomMusic)
+}
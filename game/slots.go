@@ -0,0 +1,49 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// NewSlotMenu lists every save slot, calling choose with the one selected. The slots this build can't load can't be
+// selected, so they're never started over.
+func NewSlotMenu(choose func(slot int)) *Menu {
+	m := &Menu{Title: "Select Save", Modal: true}
+	for slot := 1; slot <= SaveSlots; slot++ {
+		label, loadable := slotLabel(slot)
+		item := MenuItem{Label: staticLabel(label)}
+		if loadable {
+			item.Select = func() {
+				choose(slot)
+				m.Close()
+			}
+		}
+		m.Items = append(m.Items, item)
+	}
+
+	return m
+}
+
+func slotLabel(slot int) (string, bool) {
+	data, err := readSlot(slot)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Sprintf("Slot %d  Empty", slot), true
+		}
+
+		return fmt.Sprintf("Slot %d  Unreadable", slot), false
+	}
+	saveData, err := decodeSave(data)
+	var newerErr *NewerSaveError
+	switch {
+	case errors.As(err, &newerErr):
+		return fmt.Sprintf("Slot %d  Newer version", slot), false
+	case refusedSave(err):
+		return fmt.Sprintf("Slot %d  Unreadable", slot), false
+	case err != nil:
+		return fmt.Sprintf("Slot %d  Corrupt", slot), true
+	}
+
+	return fmt.Sprintf("Slot %d  Exp %d", slot, saveData.PlayerData.Exp), true
+}
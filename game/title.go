@@ -0,0 +1,96 @@
+package game
+
+import (
+	"game/assets"
+	"game/utils"
+	"game/vars"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	gameTitle     = "Castle"
+	titleY        = 12
+	titleMenuY    = 30
+	creditsSpeed  = 10
+	creditsLineH  = 9
+	creditsMargin = 8
+)
+
+var creditsLines = []string{
+	gameTitle,
+	"",
+	"A game by master-both",
+	"",
+	"Made with Ebitengine",
+	"Fonts m5x7 and m6x11",
+	"by Daniel Linssen",
+	"",
+	"Thanks for playing",
+}
+
+// TitleScene is the first screen, leading to the save slots, the settings and the credits.
+type TitleScene struct {
+	menu   Menu
+	scenes *SceneStack
+}
+
+func NewTitleScene(scenes *SceneStack) *TitleScene { return &TitleScene{scenes: scenes} }
+
+func (s *TitleScene) Init() {
+	vars.Pad = utils.NewControlPack()
+	vars.Audio.Stop()
+	s.menu = Menu{OffsetY: titleMenuY, Modal: true}
+	s.menu.Items = []MenuItem{
+		{Label: staticLabel("Start"), Select: func() {
+			slots := NewSlotMenu(func(slot int) { s.scenes.Switch(NewGameScene(s.scenes, slot)) })
+			slots.Modal = false
+			s.scenes.Push(slots)
+		}},
+		{Label: staticLabel("Settings"), Select: func() { s.scenes.Push(&SettingsMenu{}) }},
+		{Label: staticLabel("Credits"), Select: func() { s.scenes.Push(&CreditsScene{}) }},
+		{Label: staticLabel("Quit"), Select: func() { s.scenes.Fail(ebiten.Termination) }},
+	}
+}
+
+func (s *TitleScene) Update(dt float64) bool {
+	s.menu.Update(dt)
+
+	return false
+}
+
+func (s *TitleScene) Draw(screen *ebiten.Image) {
+	s.menu.Draw(screen)
+	w, _ := utils.TextSize(gameTitle, assets.M6x11Font)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(vars.ScreenWidth-w)/2, titleY)
+	op.ColorScale.ScaleWithColor(textColor)
+	utils.DrawText(screen, gameTitle, assets.M6x11Font, op)
+}
+
+// CreditsScene scrolls the credits up, until they're gone or any menu key is pressed.
+type CreditsScene struct {
+	y float64
+}
+
+func (s *CreditsScene) Init() { s.y = vars.ScreenHeight }
+
+func (s *CreditsScene) Update(dt float64) bool {
+	s.y -= creditsSpeed * dt
+	skipped := vars.Controls.KeyPressed(utils.KeyJump) || vars.Controls.KeyPressed(utils.KeyGuard) ||
+		vars.Controls.KeyPressed(utils.KeyMenu)
+
+	return skipped || s.y < -float64(len(creditsLines)*creditsLineH+creditsMargin)
+}
+
+func (s *CreditsScene) Draw(screen *ebiten.Image) {
+	op := &ebiten.DrawImageOptions{}
+	for i, line := range creditsLines {
+		w, _ := utils.TextSize(line, assets.NanoFont)
+		op.GeoM.Reset()
+		op.GeoM.Translate(float64(vars.ScreenWidth-w)/2, s.y+float64(i*creditsLineH))
+		op.ColorScale.Reset()
+		op.ColorScale.ScaleWithColor(textColor)
+		utils.DrawText(screen, line, assets.NanoFont, op)
+	}
+}
@@ -1,6 +1,7 @@
 package bump
 
 import (
+	"cmp"
 	"math"
 	"slices"
 	"sync"
@@ -174,6 +175,21 @@ func (s *Space) Rect(item Item) Rect {
 	return s.rects[item]
 }
 
+// Items returns every item with the tag, along with its rect.
+func (s *Space) Items(tag Tag) map[Item]Rect {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	items := map[Item]Rect{}
+	for item, tags := range s.tags {
+		if slices.Contains(tags, tag) {
+			items[item] = s.rects[item]
+		}
+	}
+
+	return items
+}
+
 func (s *Space) Has(item Item, tags ...Tag) bool {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
@@ -304,6 +320,48 @@ func (s *Space) Query(rect Rect, filter SelectFilter, tags ...Tag) []*Collision
 	return s.Project(nil, rect, Vec2{rect.X, rect.Y}, projectFilter, tags...)
 }
 
+// QuerySegment returns the items crossed by the segment from p1 to p2, sorted from the closest to p1.
+func (s *Space) QuerySegment(p1, p2 Vec2, filter SelectFilter, tags ...Tag) []Item {
+	if filter == nil {
+		filter = func(_ Item) bool { return true }
+	}
+	if len(tags) == 0 {
+		tags = []Tag{""}
+	}
+	type crossing struct {
+		item Item
+		ti   float64
+	}
+	rect := Rect{X: math.Min(p1.X, p2.X), Y: math.Min(p1.Y, p2.Y), W: math.Abs(p2.X - p1.X), H: math.Abs(p2.Y - p1.Y)}
+	visited := map[Item]bool{}
+	var crossings []crossing
+	s.mutex.RLock()
+	for _, cell := range s.cellCoords(rect) {
+		for _, tag := range tags {
+			for other := range s.searchSpace[location{tag, cell}] {
+				if visited[other] {
+					continue
+				}
+				visited[other] = true
+				if ti1, ti2, _, ok := lineSegmentIntersection(s.rects[other], p1, p2); ok && ti1 < 1 && ti2 > 0 {
+					crossings = append(crossings, crossing{other, math.Max(ti1, 0)})
+				}
+			}
+		}
+	}
+	s.mutex.RUnlock()
+
+	slices.SortFunc(crossings, func(a, b crossing) int { return cmp.Compare(a.ti, b.ti) })
+	var items []Item
+	for _, c := range crossings {
+		if filter(c.item) {
+			items = append(items, c.item)
+		}
+	}
+
+	return items
+}
+
 func Overlaps(r1, r2 Rect) bool {
 	return rectContainsPoint(rectDiff(r1, r2), Vec2{})
 }
@@ -4,7 +4,7 @@ import (
 	"game/libs/bump"
 	"image"
 	"math"
-	"math/rand"
+	"math/rand/v2"
 
 	"github.com/tanema/gween"
 	"github.com/tanema/gween/ease"
@@ -13,6 +13,7 @@ import (
 const (
 	defaultTransitionDuration = 0.8
 	defaultStiffness          = 9
+	shakeSeed                 = 1
 )
 
 type Recter interface {
@@ -31,15 +32,22 @@ type Camera struct {
 	stiffness                int
 	transitionDuration       float32
 	betweenRooms             bool
+	rand                     *rand.Rand
 }
 
 func New(w, h float64) *Camera {
-	return &Camera{w: w, h: h, transitionDuration: defaultTransitionDuration, stiffness: defaultStiffness}
+	return &Camera{
+		w: w, h: h,
+		transitionDuration: defaultTransitionDuration, stiffness: defaultStiffness,
+		rand: rand.New(rand.NewPCG(shakeSeed, shakeSeed)), //nolint: gosec
+	}
 }
 
 func (c *Camera) Position() (float64, float64) { return c.x, c.y }
 func (c *Camera) SetPosition(x, y float64)     { c.x, c.y = x, y }
 func (c *Camera) SetRooms(rooms []bump.Rect)   { c.rooms = rooms }
+func (c *Camera) Rooms() []bump.Rect           { return c.rooms }
+func (c *Camera) Room() *bump.Rect             { return c.borders }
 func (c *Camera) Follow(e Recter) {
 	c.shakeTween = nil
 	c.transitionTween = nil
@@ -108,8 +116,8 @@ func (c *Camera) Update(dt float64) {
 			c.shakeTween = nil
 		}
 
-		shakex := (rand.Float64()*2 - 1) * c.shakeMagnitude * float64(prog)
-		shakey := (rand.Float64()*2 - 1) * c.shakeMagnitude * float64(prog)
+		shakex := (c.rand.Float64()*2 - 1) * c.shakeMagnitude * float64(prog)
+		shakey := (c.rand.Float64()*2 - 1) * c.shakeMagnitude * float64(prog)
 		c.Translate(shakex, shakey)
 	}
 }
@@ -0,0 +1,334 @@
+package nav
+
+import (
+	"cmp"
+	"container/heap"
+	"game/libs/bump"
+	"math"
+	"slices"
+)
+
+// Navigation graph of the walkable surfaces of a bump.Space, linked by jumps and drops.
+
+const (
+	edgeInset     = 2.0 // How far into a surface a link lands.
+	jumpClearance = 4.0 // How far from a solid edge a jump around it takes off.
+	feetMargin    = 4.0
+)
+
+type LinkType int
+
+const (
+	Jump LinkType = iota
+	Drop
+	DropThrough
+)
+
+// Surface is the walkable top of one or more platforms.
+type Surface struct {
+	X1, X2, Y   float64
+	Passthrough bool
+	Links       []*Link
+}
+
+type Link struct {
+	Type       LinkType
+	From, To   *Surface
+	FromX, ToX float64
+	Cost       float64
+}
+
+// Config holds the movement the links are built for, the smaller the more enemies can follow them.
+type Config struct {
+	Gravity, JumpSpeed, AirSpeed float64
+}
+
+type Graph struct {
+	Surfaces []*Surface
+	config   Config
+	space    *bump.Space
+}
+
+func (c Config) JumpHeight() float64 { return c.JumpSpeed * c.JumpSpeed / (2 * c.Gravity) }
+
+// Build reads the "map" items of space, solid ones and passthrough platforms being walkable, ladders and slopes not.
+func Build(space *bump.Space, config Config) *Graph {
+	var solids, platforms []bump.Rect
+	for item, rect := range space.Items("map") {
+		switch {
+		case space.Has(item, "slope") || space.Has(item, "ladder"):
+		case space.Has(item, "passthrough"):
+			platforms = append(platforms, rect)
+		default:
+			solids = append(solids, rect)
+		}
+	}
+
+	g := &Graph{config: config, space: space}
+	for _, rect := range solids {
+		g.Surfaces = append(g.Surfaces, uncovered(rect, false, solids)...)
+	}
+	for _, rect := range platforms {
+		g.Surfaces = append(g.Surfaces, uncovered(rect, true, solids)...)
+	}
+	g.merge()
+	for _, from := range g.Surfaces {
+		for _, to := range g.Surfaces {
+			if from != to {
+				g.link(from, to)
+			}
+		}
+	}
+
+	return g
+}
+
+// SurfaceAt returns the surface under the feet at x, y, nil when in the air.
+func (g *Graph) SurfaceAt(x, feetY float64) *Surface {
+	var closest *Surface
+	for _, surface := range g.Surfaces {
+		if x < surface.X1 || x > surface.X2 || math.Abs(surface.Y-feetY) > feetMargin {
+			continue
+		}
+		if closest == nil || math.Abs(surface.Y-feetY) < math.Abs(closest.Y-feetY) {
+			closest = surface
+		}
+	}
+
+	return closest
+}
+
+// Path returns the links to take from one point to the other, false when there's no way.
+// Both points are feet positions, an empty path means they are on the same surface.
+func (g *Graph) Path(fromX, fromY, toX, toY float64) ([]*Link, bool) {
+	start, goal := g.SurfaceAt(fromX, fromY), g.SurfaceAt(toX, toY)
+	if start == nil || goal == nil {
+		return nil, false
+	}
+	if start == goal {
+		return nil, true
+	}
+
+	best := map[*Link]float64{}
+	prev := map[*Link]*Link{}
+	queue := &linkQueue{}
+	expand := func(from *Link, surface *Surface, x, cost float64) {
+		for _, link := range surface.Links {
+			linkCost := cost + math.Abs(x-link.FromX) + link.Cost
+			if old, ok := best[link]; ok && old <= linkCost {
+				continue
+			}
+			best[link], prev[link] = linkCost, from
+			heap.Push(queue, queuedLink{link, linkCost})
+		}
+	}
+	expand(nil, start, fromX, 0)
+
+	var found *Link
+	foundCost := math.Inf(1)
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(queuedLink) //nolint: forcetypeassert
+		if item.cost >= foundCost {
+			break
+		}
+		if item.cost > best[item.link] {
+			continue
+		}
+		if item.link.To == goal {
+			if cost := item.cost + math.Abs(item.link.ToX-toX); cost < foundCost {
+				found, foundCost = item.link, cost
+			}
+
+			continue
+		}
+		expand(item.link, item.link.To, item.link.ToX, item.cost)
+	}
+	if found == nil {
+		return nil, false
+	}
+
+	var path []*Link
+	for link := found; link != nil; link = prev[link] {
+		path = append(path, link)
+	}
+	slices.Reverse(path)
+
+	return path, true
+}
+
+// uncovered returns the parts of the top of rect not covered by the solids.
+func uncovered(rect bump.Rect, passthrough bool, solids []bump.Rect) []*Surface {
+	segments := [][2]float64{{rect.X, rect.X + rect.W}}
+	for _, solid := range solids {
+		if solid == rect || solid.Y >= rect.Y || solid.Y+solid.H < rect.Y {
+			continue
+		}
+		var cut [][2]float64
+		for _, segment := range segments {
+			if solid.X > segment[0] {
+				cut = append(cut, [2]float64{segment[0], math.Min(segment[1], solid.X)})
+			}
+			if right := solid.X + solid.W; right < segment[1] {
+				cut = append(cut, [2]float64{math.Max(segment[0], right), segment[1]})
+			}
+		}
+		segments = cut
+	}
+
+	surfaces := make([]*Surface, 0, len(segments))
+	for _, segment := range segments {
+		if segment[1] > segment[0] {
+			surfaces = append(surfaces, &Surface{X1: segment[0], X2: segment[1], Y: rect.Y, Passthrough: passthrough})
+		}
+	}
+
+	return surfaces
+}
+
+// merge joins the surfaces touching each other at the same height, tiles being one rect each.
+func (g *Graph) merge() {
+	slices.SortFunc(g.Surfaces, func(a, b *Surface) int {
+		if c := cmp.Compare(a.Y, b.Y); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.X1, b.X1)
+	})
+	merged := g.Surfaces[:0]
+	for _, surface := range g.Surfaces {
+		if len(merged) > 0 {
+			if last := merged[len(merged)-1]; last.Y == surface.Y && surface.X1 <= last.X2 {
+				last.X2 = math.Max(last.X2, surface.X2)
+				last.Passthrough = last.Passthrough && surface.Passthrough
+
+				continue
+			}
+		}
+		merged = append(merged, surface)
+	}
+	g.Surfaces = merged
+}
+
+func (g *Graph) link(from, to *Surface) {
+	if to.Y > from.Y {
+		g.linkDrop(from, to, from.X1, -1)
+		g.linkDrop(from, to, from.X2, 1)
+		if from.Passthrough {
+			g.linkDropThrough(from, to)
+		}
+
+		return
+	}
+	g.linkJump(from, to)
+}
+
+func (g *Graph) linkDrop(from, to *Surface, edgeX, dir float64) {
+	height := to.Y - from.Y
+	drift := g.config.AirSpeed * math.Sqrt(2*height/g.config.Gravity)
+	toX := edgeX + dir*edgeInset
+	if toX < to.X1 || toX > to.X2 {
+		if toX = to.X1 + edgeInset; dir < 0 {
+			toX = to.X2 - edgeInset
+		}
+		if math.Abs(toX-edgeX) > drift || (toX-edgeX)*dir < 0 {
+			return
+		}
+	}
+	if g.blocked(edgeX+dir, from.Y-1, toX, to.Y-1) || g.surfaceBetween(from, to, toX) {
+		return
+	}
+	g.addLink(Drop, from, to, edgeX, toX)
+}
+
+func (g *Graph) linkDropThrough(from, to *Surface) {
+	x1, x2 := math.Max(from.X1, to.X1), math.Min(from.X2, to.X2)
+	if x1 >= x2 {
+		return
+	}
+	x := (x1 + x2) / 2
+	if g.blocked(x, from.Y+1, x, to.Y-1) || g.surfaceBetween(from, to, x) {
+		return
+	}
+	g.addLink(DropThrough, from, to, x, x)
+}
+
+func (g *Graph) linkJump(from, to *Surface) {
+	height := from.Y - to.Y
+	if height > g.config.JumpHeight() {
+		return
+	}
+	v := g.config.JumpSpeed
+	airTime := (v + math.Sqrt(v*v-2*g.config.Gravity*height)) / g.config.Gravity
+	reach := g.config.AirSpeed * airTime
+
+	var fromX, toX float64
+	switch {
+	case to.X1 >= from.X2:
+		fromX, toX = from.X2, to.X1+edgeInset
+	case to.X2 <= from.X1:
+		fromX, toX = from.X1, to.X2-edgeInset
+	case height <= 0:
+		// Overlapping surfaces at the same height are the same one.
+		return
+	case to.Passthrough:
+		fromX = (math.Max(from.X1, to.X1) + math.Min(from.X2, to.X2)) / 2
+		toX = fromX
+	case from.X2 > to.X2:
+		fromX, toX = math.Min(from.X2, to.X2+jumpClearance), to.X2-edgeInset
+	case from.X1 < to.X1:
+		fromX, toX = math.Max(from.X1, to.X1-jumpClearance), to.X1+edgeInset
+	default:
+		return
+	}
+	if math.Abs(toX-fromX) > reach {
+		return
+	}
+	if g.blocked(fromX, from.Y-1, fromX, to.Y-1) || g.blocked(fromX, to.Y-1, toX, to.Y-1) {
+		return
+	}
+	g.addLink(Jump, from, to, fromX, toX)
+}
+
+func (g *Graph) addLink(linkType LinkType, from, to *Surface, fromX, toX float64) {
+	link := &Link{linkType, from, to, fromX, toX, math.Abs(toX-fromX) + math.Abs(to.Y-from.Y)}
+	from.Links = append(from.Links, link)
+}
+
+// blocked reports whether a solid is in the way of the segment, platforms never being.
+func (g *Graph) blocked(x1, y1, x2, y2 float64) bool {
+	walls := g.space.QuerySegment(bump.Vec2{X: x1, Y: y1}, bump.Vec2{X: x2, Y: y2}, func(item bump.Item) bool {
+		return !g.space.Has(item, "slope") && !g.space.Has(item, "passthrough")
+	}, "map")
+
+	return len(walls) > 0
+}
+
+// surfaceBetween reports whether falling at x from one surface lands on another before reaching the last one.
+func (g *Graph) surfaceBetween(from, to *Surface, x float64) bool {
+	for _, surface := range g.Surfaces {
+		if surface != from && surface != to && surface.Y > from.Y && surface.Y < to.Y && x >= surface.X1 && x <= surface.X2 {
+			return true
+		}
+	}
+
+	return false
+}
+
+type queuedLink struct {
+	link *Link
+	cost float64
+}
+
+type linkQueue []queuedLink
+
+func (q linkQueue) Len() int           { return len(q) }
+func (q linkQueue) Less(i, j int) bool { return q[i].cost < q[j].cost }
+func (q linkQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *linkQueue) Push(x any)        { *q = append(*q, x.(queuedLink)) } //nolint: forcetypeassert
+func (q *linkQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return item
+}
@@ -0,0 +1,69 @@
+package nav_test
+
+import (
+	"game/libs/bump"
+	"game/libs/nav"
+	"testing"
+)
+
+// A floor made of two tiles, a ledge above it, a platform to drop through and a ledge too high to reach.
+func buildGraph() *nav.Graph {
+	space := bump.NewSpace()
+	solids := []bump.Rect{
+		{X: 0, Y: 100, W: 100, H: 10}, {X: 100, Y: 100, W: 100, H: 10}, {X: 60, Y: 60, W: 40, H: 10},
+		{X: 300, Y: 20, W: 40, H: 10},
+	}
+	for _, rect := range solids {
+		space.Set(&rect, rect, "map")
+	}
+	platform := bump.Rect{X: 150, Y: 70, W: 30, H: 4}
+	space.Set(&platform, platform, "map", "passthrough")
+
+	return nav.Build(space, nav.Config{Gravity: 600, JumpSpeed: 250, AirSpeed: 100})
+}
+
+func TestSurfaces(t *testing.T) {
+	graph := buildGraph()
+	if len(graph.Surfaces) != 4 {
+		t.Errorf("%d surfaces, want 4 with the floor tiles merged", len(graph.Surfaces))
+	}
+	floor := graph.SurfaceAt(10, 100)
+	if floor == nil || floor.X1 != 0 || floor.X2 != 200 {
+		t.Fatalf("floor surface = %+v, want one from 0 to 200", floor)
+	}
+	if surface := graph.SurfaceAt(10, 50); surface != nil {
+		t.Errorf("surface %+v found in the air", surface)
+	}
+
+	platform := graph.SurfaceAt(160, 70)
+	if platform == nil || !platform.Passthrough {
+		t.Fatalf("platform surface = %+v, want a passthrough one", platform)
+	}
+	dropsThrough := false
+	for _, link := range platform.Links {
+		dropsThrough = dropsThrough || (link.Type == nav.DropThrough && link.To == floor)
+	}
+	if !dropsThrough {
+		t.Error("no link dropping through the platform to the floor")
+	}
+}
+
+func TestPath(t *testing.T) {
+	graph := buildGraph()
+	ledge := graph.SurfaceAt(80, 60)
+
+	path, ok := graph.Path(10, 100, 80, 60)
+	if !ok || len(path) != 1 || path[0].Type != nav.Jump || path[0].To != ledge {
+		t.Errorf("path from the floor to the ledge = %v, %v, want a single jump", path, ok)
+	}
+	path, ok = graph.Path(80, 60, 10, 100)
+	if !ok || len(path) != 1 || path[0].Type != nav.Drop {
+		t.Errorf("path from the ledge to the floor = %v, %v, want a single drop", path, ok)
+	}
+	if path, ok = graph.Path(10, 100, 190, 100); !ok || len(path) != 0 {
+		t.Errorf("path along the floor = %v, %v, want an empty one", path, ok)
+	}
+	if _, ok = graph.Path(10, 100, 320, 20); ok {
+		t.Error("path found to the ledge too high to reach")
+	}
+}
@@ -0,0 +1,157 @@
+package sound
+
+import (
+	"game/libs/camera"
+	"log"
+	"math"
+)
+
+const (
+	musicFadeSeconds = 1.0
+	repeatSeconds    = 0.05 // The same sound started again sooner is skipped.
+	maxPan           = 0.6
+)
+
+// Manager plays the sound effects, positioned from the Camera center, and one music loop at a time. A nil Manager
+// plays nothing.
+type Manager struct {
+	Sink                    Sink
+	Camera                  *camera.Camera
+	Master, Music, SFX      float64
+	sounds                  map[string]*Sound
+	voices                  []Voice
+	lastPlayed              map[string]float64
+	missing                 map[string]bool
+	time                    float64
+	music, fadingMusic      Voice
+	musicName               string
+	musicFade, fadingVolume float64
+}
+
+func NewManager(sink Sink, sounds map[string]*Sound) *Manager {
+	return &Manager{
+		Sink: sink, Master: 1, Music: 1, SFX: 1,
+		sounds: sounds, lastPlayed: map[string]float64{}, missing: map[string]bool{},
+	}
+}
+
+func (m *Manager) Play(name string) { m.play(name, 1, 0) }
+
+// PlayAt plays name from the world position x, y, fading out past half a screen away from the camera center and
+// panned to its side.
+func (m *Manager) PlayAt(name string, x, y float64) {
+	if m == nil {
+		return
+	}
+	if m.Camera == nil {
+		m.Play(name)
+
+		return
+	}
+	bounds := m.Camera.Bounds()
+	halfW := float64(bounds.Dx()) / 2
+	dx := x - float64(bounds.Min.X) - halfW
+	dy := y - float64(bounds.Min.Y) - float64(bounds.Dy())/2
+	volume := math.Max(0, math.Min(1, 2-math.Hypot(dx, dy)/halfW))
+	pan := math.Max(-1, math.Min(1, dx/halfW)) * maxPan
+	m.play(name, volume, pan)
+}
+
+// PlayMusic crossfades to the name loop, an empty name fading out to silence.
+func (m *Manager) PlayMusic(name string) {
+	if m == nil || name == m.musicName {
+		return
+	}
+	m.musicName = name
+	if m.fadingMusic != nil {
+		m.fadingMusic.Close()
+	}
+	m.fadingMusic, m.fadingVolume = m.music, m.musicFade
+	m.music, m.musicFade = nil, 0
+	if name == "" {
+		return
+	}
+	if sound := m.sound(name); sound != nil {
+		m.music = m.Sink.Play(sound, 0, 0, true)
+	}
+}
+
+func (m *Manager) MusicName() string {
+	if m == nil {
+		return ""
+	}
+
+	return m.musicName
+}
+
+func (m *Manager) Update(dt float64) {
+	if m == nil {
+		return
+	}
+	m.time += dt
+	playing := m.voices[:0]
+	for _, voice := range m.voices {
+		if voice.IsPlaying() {
+			playing = append(playing, voice)
+		} else {
+			voice.Close()
+		}
+	}
+	clear(m.voices[len(playing):])
+	m.voices = playing
+
+	fade := dt / musicFadeSeconds
+	if m.fadingMusic != nil {
+		if m.fadingVolume -= fade; m.fadingVolume <= 0 {
+			m.fadingMusic.Close()
+			m.fadingMusic = nil
+		} else {
+			m.fadingMusic.SetVolume(m.fadingVolume * m.Music * m.Master)
+		}
+	}
+	if m.music != nil {
+		m.musicFade = math.Min(1, m.musicFade+fade)
+		m.music.SetVolume(m.musicFade * m.Music * m.Master)
+	}
+}
+
+// Stop silences everything at once.
+func (m *Manager) Stop() {
+	if m == nil {
+		return
+	}
+	for _, voice := range append(m.voices, m.music, m.fadingMusic) {
+		if voice != nil {
+			voice.Close()
+		}
+	}
+	m.voices, m.music, m.fadingMusic, m.musicName = nil, nil, nil, ""
+}
+
+func (m *Manager) play(name string, volume, pan float64) {
+	if m == nil {
+		return
+	}
+	if volume *= m.SFX * m.Master; volume <= 0 {
+		return
+	}
+	if last, ok := m.lastPlayed[name]; ok && m.time-last < repeatSeconds {
+		return
+	}
+	sound := m.sound(name)
+	if sound == nil {
+		return
+	}
+	m.lastPlayed[name] = m.time
+	m.voices = append(m.voices, m.Sink.Play(sound, volume, pan, false))
+}
+
+func (m *Manager) sound(name string) *Sound {
+	sound := m.sounds[name]
+	if sound == nil && !m.missing[name] {
+		m.missing[name] = true
+		log.Printf("sound: %s not found", name)
+	}
+
+	return sound
+}
@@ -0,0 +1,160 @@
+package sound
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+)
+
+// SampleRate every sound is decoded to, 16 bit stereo.
+const SampleRate = 44100
+
+const bytesPerFrame = 4
+
+// Sound is a decoded sound, ready to be played by any Sink.
+type Sound struct {
+	Name string
+	pcm  []byte
+}
+
+// Voice is a playing sound, *audio.Player being one.
+type Voice interface {
+	SetVolume(volume float64)
+	IsPlaying() bool
+	Close() error
+}
+
+// Sink plays sounds, on the ebiten audio context or nowhere for headless runs.
+type Sink interface {
+	Play(sound *Sound, volume, pan float64, loop bool) Voice
+}
+
+// ContextSink plays through the ebiten audio context, created on first use.
+type ContextSink struct {
+	context *audio.Context
+}
+
+func NewContextSink() *ContextSink {
+	context := audio.CurrentContext()
+	if context == nil {
+		context = audio.NewContext(SampleRate)
+	}
+
+	return &ContextSink{context: context}
+}
+
+func (s *ContextSink) Play(sound *Sound, volume, pan float64, loop bool) Voice {
+	var stream io.ReadSeeker = bytes.NewReader(sound.pcm)
+	if pan != 0 {
+		stream = newPanStream(stream, pan)
+	}
+	if loop {
+		stream = audio.NewInfiniteLoop(stream, int64(len(sound.pcm)))
+	}
+	player, err := s.context.NewPlayer(stream)
+	if err != nil {
+		log.Printf("sound: error playing %s: %v", sound.Name, err)
+
+		return &nullVoice{}
+	}
+	player.SetVolume(volume)
+	player.Play()
+
+	return player
+}
+
+// NullSink plays nothing, keeping the names of the played sounds so tests can check them.
+type NullSink struct {
+	Played []string
+}
+
+func (s *NullSink) Play(sound *Sound, volume, _ float64, loop bool) Voice {
+	s.Played = append(s.Played, sound.Name)
+
+	return &nullVoice{volume: volume, playing: loop}
+}
+
+type nullVoice struct {
+	volume  float64
+	playing bool
+}
+
+func (v *nullVoice) SetVolume(volume float64) { v.volume = volume }
+func (v *nullVoice) IsPlaying() bool          { return v.playing }
+func (v *nullVoice) Close() error {
+	v.playing = false
+
+	return nil
+}
+
+// LoadAll decodes every wav file of the fsys dir, naming them after the file without extension.
+func LoadAll(fsys fs.FS, dir string) (map[string]*Sound, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	sounds := map[string]*Sound{}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".wav" {
+			continue
+		}
+		file, err := fsys.Open(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(entry.Name(), ".wav")
+		sounds[name], err = decode(name, file)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sounds, nil
+}
+
+func decode(name string, r io.Reader) (*Sound, error) {
+	stream, err := wav.DecodeWithSampleRate(SampleRate, r)
+	if err != nil {
+		return nil, fmt.Errorf("sound %s: %w", name, err)
+	}
+	pcm, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, fmt.Errorf("sound %s: %w", name, err)
+	}
+
+	return &Sound{Name: name, pcm: pcm[:len(pcm)/bytesPerFrame*bytesPerFrame]}, nil
+}
+
+// panStream lowers one channel of the pcm it reads, so sounds are panned as they play instead of copied.
+type panStream struct {
+	io.ReadSeeker
+	left, right float64
+}
+
+// newPanStream pans the pcm of src, -1 being only left and 1 only right.
+func newPanStream(src io.ReadSeeker, pan float64) *panStream {
+	return &panStream{ReadSeeker: src, left: math.Min(1, 1-pan), right: math.Min(1, 1+pan)}
+}
+
+// Read reads whole frames only, the seeks being frame aligned too.
+func (s *panStream) Read(p []byte) (int, error) {
+	n, err := s.ReadSeeker.Read(p[:len(p)/bytesPerFrame*bytesPerFrame])
+	for i := 0; i+bytesPerFrame <= n; i += bytesPerFrame {
+		l := int16(binary.LittleEndian.Uint16(p[i:]))                             //nolint: gosec
+		r := int16(binary.LittleEndian.Uint16(p[i+2:]))                           //nolint: gosec
+		binary.LittleEndian.PutUint16(p[i:], uint16(int16(float64(l)*s.left)))    //nolint: gosec
+		binary.LittleEndian.PutUint16(p[i+2:], uint16(int16(float64(r)*s.right))) //nolint: gosec
+	}
+
+	return n, err
+}
@@ -0,0 +1,49 @@
+package sound
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func TestPanStream(t *testing.T) {
+	pcm := make([]byte, 2*bytesPerFrame)
+	for i := 0; i < len(pcm); i += 2 {
+		binary.LittleEndian.PutUint16(pcm[i:], 1000)
+	}
+	stream := newPanStream(bytes.NewReader(pcm), 0.5)
+	for range 2 {
+		if _, err := stream.Seek(0, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		out, err := io.ReadAll(stream)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(out) != len(pcm) {
+			t.Fatalf("read %d bytes, want %d", len(out), len(pcm))
+		}
+		for i := 0; i < len(out); i += bytesPerFrame {
+			l, r := binary.LittleEndian.Uint16(out[i:]), binary.LittleEndian.Uint16(out[i+2:])
+			if l != 500 || r != 1000 {
+				t.Errorf("frame %d = %d,%d, want the left channel halved to 500,1000", i/bytesPerFrame, l, r)
+			}
+		}
+	}
+	if binary.LittleEndian.Uint16(pcm) != 1000 {
+		t.Error("panning changed the sound pcm")
+	}
+}
+
+func TestNilManager(t *testing.T) {
+	var m *Manager
+	m.Play("hit")
+	m.PlayAt("hit", 10, 10)
+	m.PlayMusic("ambient")
+	m.Update(1)
+	m.Stop()
+	if name := m.MusicName(); name != "" {
+		t.Errorf("nil manager music = %q, want none", name)
+	}
+}
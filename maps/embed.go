@@ -2,7 +2,7 @@ package maps
 
 import "embed"
 
-// IntroFS is the embed.FS for the intro map.
+// FS holds the maps of every area folder with their tilesets, by their path from this folder.
 //
-//go:embed intro/*.png intro/*.tsx intro/playground_imp.tmx
-var IntroFS embed.FS
+//go:embed intro/*.png intro/*.tsx intro/*.tmx
+var FS embed.FS
@@ -37,17 +37,11 @@ var (
 
 type Light struct{ X, Y, Size float64 }
 
-func Load(worldMap *core.Map, lightGIDs []uint32) {
+func Load() {
 	var err error
 	if lightShader, err = ebiten.NewShader(lightShaderData); err != nil {
 		log.Fatal(err)
 	}
-	for _, gid := range lightGIDs {
-		for _, position := range worldMap.FindTilePosition(gid) {
-			AddLight(position[0]+tileSize/2, position[1]+tileSize/2, LightSize)
-		}
-	}
-
 	if phosphoreShader, err = ebiten.NewShader(phosphoreShaderData); err != nil {
 		log.Fatal(err)
 	}
@@ -62,6 +56,16 @@ func Load(worldMap *core.Map, lightGIDs []uint32) {
 	}
 }
 
+// LoadLights replaces the lights of the previous map with one on each tile of lightGIDs.
+func LoadLights(worldMap *core.Map, lightGIDs []uint32) {
+	lights = lights[:1]
+	for _, gid := range lightGIDs {
+		for _, position := range worldMap.FindTilePosition(gid) {
+			AddLight(position[0]+tileSize/2, position[1]+tileSize/2, LightSize)
+		}
+	}
+}
+
 func Update(dt float64) { shaderTime += float32(dt) }
 
 func DrawLights(pipeline *core.Pipeline, screen *ebiten.Image) {
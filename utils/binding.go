@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	AxisDeadZone = 0.5
+	padPrefix    = "pad:"
+)
+
+type BindingSource int
+
+const (
+	SourceKey BindingSource = iota
+	SourceButton
+	SourceAxis
+)
+
+// Binding is a keyboard key, a standard layout gamepad button or one direction of a gamepad axis.
+type Binding struct {
+	Source BindingSource
+	Key    ebiten.Key
+	Button ebiten.StandardGamepadButton
+	Axis   ebiten.StandardGamepadAxis
+	Dir    float64
+}
+
+var (
+	buttonNames = map[ebiten.StandardGamepadButton]string{
+		ebiten.StandardGamepadButtonRightBottom:      "A",
+		ebiten.StandardGamepadButtonRightRight:       "B",
+		ebiten.StandardGamepadButtonRightLeft:        "X",
+		ebiten.StandardGamepadButtonRightTop:         "Y",
+		ebiten.StandardGamepadButtonFrontTopLeft:     "LB",
+		ebiten.StandardGamepadButtonFrontTopRight:    "RB",
+		ebiten.StandardGamepadButtonFrontBottomLeft:  "LT",
+		ebiten.StandardGamepadButtonFrontBottomRight: "RT",
+		ebiten.StandardGamepadButtonCenterLeft:       "Back",
+		ebiten.StandardGamepadButtonCenterRight:      "Start",
+		ebiten.StandardGamepadButtonLeftStick:        "LS",
+		ebiten.StandardGamepadButtonRightStick:       "RS",
+		ebiten.StandardGamepadButtonLeftTop:          "Up",
+		ebiten.StandardGamepadButtonLeftBottom:       "Down",
+		ebiten.StandardGamepadButtonLeftLeft:         "Left",
+		ebiten.StandardGamepadButtonLeftRight:        "Right",
+		ebiten.StandardGamepadButtonCenterCenter:     "Home",
+	}
+	axisNames = map[ebiten.StandardGamepadAxis]string{
+		ebiten.StandardGamepadAxisLeftStickHorizontal:  "LX",
+		ebiten.StandardGamepadAxisLeftStickVertical:    "LY",
+		ebiten.StandardGamepadAxisRightStickHorizontal: "RX",
+		ebiten.StandardGamepadAxisRightStickVertical:   "RY",
+	}
+	gamepadIDs []ebiten.GamepadID
+)
+
+func KeyBinding(key ebiten.Key) Binding { return Binding{Source: SourceKey, Key: key} }
+
+func ButtonBinding(button ebiten.StandardGamepadButton) Binding {
+	return Binding{Source: SourceButton, Button: button}
+}
+
+func AxisBinding(axis ebiten.StandardGamepadAxis, dir float64) Binding {
+	return Binding{Source: SourceAxis, Axis: axis, Dir: dir}
+}
+
+// Gamepad reports whether the binding is read from a gamepad instead of the keyboard.
+func (b Binding) Gamepad() bool { return b.Source != SourceKey }
+
+func (b Binding) Down() bool {
+	if b.Source == SourceKey {
+		return ebiten.IsKeyPressed(b.Key)
+	}
+	for _, id := range gamepadIDs {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		switch b.Source {
+		case SourceButton:
+			if ebiten.IsStandardGamepadButtonPressed(id, b.Button) {
+				return true
+			}
+		case SourceAxis:
+			if ebiten.StandardGamepadAxisValue(id, b.Axis)*b.Dir > AxisDeadZone {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Rebind puts binding in the slot of the key bindings, replacing the one there, or adds it when slot is past the last
+// one. Another key already bound to it gets the binding it replaced instead, or just loses it when there was none.
+func (cp ControlPack) Rebind(key ControlKey, slot int, binding Binding) ControlPack {
+	bindings := slices.Clone(cp[key])
+	var replaced []Binding
+	if slot < len(bindings) {
+		replaced = append(replaced, bindings[slot])
+		bindings[slot] = binding
+	} else {
+		slot = len(bindings)
+		bindings = append(bindings, binding)
+	}
+	// Bound twice to key, it stays in the slot only.
+	for i := len(bindings) - 1; i >= 0; i-- {
+		if i != slot && bindings[i] == binding {
+			bindings = slices.Delete(bindings, i, i+1)
+		}
+	}
+	for other := range cp {
+		i := slices.Index(cp[other], binding)
+		if ControlKey(other) == key || i < 0 {
+			continue
+		}
+		swapped := slices.Clone(cp[other])
+		if len(replaced) > 0 && !slices.Contains(swapped, replaced[0]) {
+			swapped[i] = replaced[0]
+		} else {
+			swapped = slices.Delete(swapped, i, i+1)
+		}
+		cp[other] = swapped
+	}
+	cp[key] = bindings
+
+	return cp
+}
+
+func (b Binding) String() string {
+	switch b.Source {
+	case SourceButton:
+		return padPrefix + buttonNames[b.Button]
+	case SourceAxis:
+		sign := "+"
+		if b.Dir < 0 {
+			sign = "-"
+		}
+
+		return padPrefix + axisNames[b.Axis] + sign
+	}
+
+	return b.Key.String()
+}
+
+func (b Binding) MarshalText() ([]byte, error) { return []byte(b.String()), nil }
+
+func (b *Binding) UnmarshalText(text []byte) error {
+	name, ok := strings.CutPrefix(string(text), padPrefix)
+	if !ok {
+		b.Source = SourceKey
+
+		return b.Key.UnmarshalText(text)
+	}
+	for button, buttonName := range buttonNames {
+		if name == buttonName {
+			*b = ButtonBinding(button)
+
+			return nil
+		}
+	}
+	if len(name) > 1 {
+		dir := map[byte]float64{'+': 1, '-': -1}[name[len(name)-1]]
+		for axis, axisName := range axisNames {
+			if dir != 0 && name[:len(name)-1] == axisName {
+				*b = AxisBinding(axis, dir)
+
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("utils: unknown gamepad binding %q", text)
+}
+
+// UnmarshalJSON also accepts the raw ebiten.Key numbers of older saves.
+func (b *Binding) UnmarshalJSON(data []byte) error {
+	var key ebiten.Key
+	if err := json.Unmarshal(data, (*int)(&key)); err == nil {
+		*b = KeyBinding(key)
+
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+
+	return b.UnmarshalText([]byte(text))
+}
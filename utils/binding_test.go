@@ -0,0 +1,48 @@
+package utils_test
+
+import (
+	"game/utils"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestRebind(t *testing.T) {
+	pad := utils.NewControlPack()
+	jump := utils.ButtonBinding(ebiten.StandardGamepadButtonRightBottom)
+	attack := utils.ButtonBinding(ebiten.StandardGamepadButtonRightLeft)
+	rebound := pad.Rebind(utils.KeyAction, 2, jump)
+
+	if !slices.Contains(rebound[utils.KeyAction], jump) || slices.Contains(rebound[utils.KeyAction], attack) {
+		t.Errorf("attack bindings = %v, want the jump button instead of its own", rebound[utils.KeyAction])
+	}
+	if !slices.Contains(rebound[utils.KeyJump], attack) || slices.Contains(rebound[utils.KeyJump], jump) {
+		t.Errorf("jump bindings = %v, want the attack button swapped in", rebound[utils.KeyJump])
+	}
+	if !slices.Contains(pad[utils.KeyJump], jump) {
+		t.Error("rebinding changed the original pack")
+	}
+	if got := rebound[utils.KeyAction][:2]; !slices.Equal(got, pad[utils.KeyAction][:2]) {
+		t.Errorf("keyboard attack bindings = %v, want them kept", got)
+	}
+}
+
+func TestRebindSlot(t *testing.T) {
+	pad := utils.NewControlPack()
+	q, n := utils.KeyBinding(ebiten.KeyQ), utils.KeyBinding(ebiten.KeyN)
+	button := utils.ButtonBinding(ebiten.StandardGamepadButtonRightBottom)
+
+	rebound := pad.Rebind(utils.KeyJump, 0, q)
+	if want := []utils.Binding{q, n, button}; !slices.Equal(rebound[utils.KeyJump], want) {
+		t.Errorf("jump bindings = %v, want only the first one replaced: %v", rebound[utils.KeyJump], want)
+	}
+	rebound = rebound.Rebind(utils.KeyJump, 3, utils.KeyBinding(ebiten.KeyE))
+	if len(rebound[utils.KeyJump]) != 4 || rebound[utils.KeyJump][3] != utils.KeyBinding(ebiten.KeyE) {
+		t.Errorf("jump bindings = %v, want E added past the last one", rebound[utils.KeyJump])
+	}
+	rebound = rebound.Rebind(utils.KeyJump, 0, n)
+	if want := []utils.Binding{n, button, utils.KeyBinding(ebiten.KeyE)}; !slices.Equal(rebound[utils.KeyJump], want) {
+		t.Errorf("jump bindings = %v, want N moved to the first slot: %v", rebound[utils.KeyJump], want)
+	}
+}
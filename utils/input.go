@@ -0,0 +1,234 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+type InputSource interface {
+	State() KeyState
+}
+
+// CaptureSource is an InputSource that also gives what the controls menu captures while rebinding a key, instead of
+// the keyboard and gamepads.
+type CaptureSource interface {
+	InputSource
+	Capture() (Capture, bool)
+}
+
+// Capture is what the player pressed while rebinding a key: the new binding, or the cancel key.
+type Capture struct {
+	Binding Binding
+	Cancel  bool
+}
+
+const (
+	captureLine   = "capture "
+	captureCancel = "cancel"
+)
+
+var (
+	// Input replaces the keyboard as the source of every ControlPack when set.
+	Input InputSource
+	// Recorder receives the state of every frame when set.
+	Recorder *InputRecorder
+)
+
+// Controls holds the ControlKey states of this frame and the last one, along with the pressed keys kept buffered.
+type Controls struct {
+	current, previous KeyState
+	buffer            map[ControlKey]float64
+}
+
+func NewControls() *Controls { return &Controls{buffer: map[ControlKey]float64{}} }
+
+// Update reads this frame ControlKey states from cp, call it once per frame.
+func (c *Controls) Update(cp ControlPack) {
+	var source InputSource = cp
+	if Input != nil {
+		source = Input
+	}
+	c.previous, c.current = c.current, source.State()
+	if Recorder != nil {
+		if err := Recorder.Record(c.current); err != nil {
+			log.Println("input: recording stopped:", err)
+			Recorder = nil
+		}
+	}
+}
+
+// TickBuffers runs the time of the key buffers, the game time rather than the frame one so they wait out a pause.
+func (c *Controls) TickBuffers(dt float64) {
+	for key := range c.buffer {
+		if c.buffer[key] -= dt; c.buffer[key] <= 0 {
+			delete(c.buffer, key)
+		}
+	}
+}
+
+func (c *Controls) KeyDown(key ControlKey) bool    { return c.current.Has(key) }
+func (c *Controls) KeyPressed(key ControlKey) bool { return c.current.Has(key) && !c.previous.Has(key) }
+func (c *Controls) KeyReleased(key ControlKey) bool {
+	return !c.current.Has(key) && c.previous.Has(key)
+}
+
+// KeyPressedBuffered keeps a press of key for the given seconds, until the returned func takes it.
+func (c *Controls) KeyPressedBuffered(key ControlKey, seconds float64) func() bool {
+	if c.KeyPressed(key) {
+		c.buffer[key] = seconds
+	}
+
+	return func() bool {
+		pressed := c.buffer[key] > 0
+		delete(c.buffer, key)
+
+		return pressed
+	}
+}
+
+type KeyState uint16
+
+func (s KeyState) Has(key ControlKey) bool { return s&(1<<key) != 0 }
+
+func (s KeyState) With(keys ...ControlKey) KeyState {
+	for _, key := range keys {
+		s |= 1 << key
+	}
+
+	return s
+}
+
+type InputScript struct {
+	Frames   []KeyState
+	Captures map[int]Capture // By frame.
+	frame    int
+}
+
+func (s *InputScript) Hold(from, to int, keys ...ControlKey) {
+	for len(s.Frames) < to {
+		s.Frames = append(s.Frames, 0)
+	}
+	for i := from; i < to; i++ {
+		s.Frames[i] = s.Frames[i].With(keys...)
+	}
+}
+
+func (s *InputScript) Press(frame int, keys ...ControlKey) { s.Hold(frame, frame+1, keys...) }
+
+func (s *InputScript) State() KeyState {
+	defer func() { s.frame++ }()
+	if s.frame >= len(s.Frames) {
+		return 0
+	}
+
+	return s.Frames[s.frame]
+}
+
+// Capture returns the capture of the frame of the last State.
+func (s *InputScript) Capture() (Capture, bool) {
+	capture, ok := s.Captures[s.frame-1]
+
+	return capture, ok
+}
+
+// ReadInputScript parses a file written by an InputRecorder, returning the seed the session was played with.
+func ReadInputScript(r io.Reader) (*InputScript, uint64, error) {
+	var seed uint64
+	reader := bufio.NewReader(r)
+	if _, err := fmt.Fscanf(reader, "seed %d\n", &seed); err != nil {
+		return nil, 0, fmt.Errorf("input: bad recording header: %w", err)
+	}
+
+	script := &InputScript{Captures: map[int]Capture{}}
+	for {
+		line, err := reader.ReadString('\n')
+		if line == "" && errors.Is(err, io.EOF) {
+			return script, seed, nil
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, 0, err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if text, ok := strings.CutPrefix(line, captureLine); ok {
+			capture := Capture{Cancel: text == captureCancel}
+			if !capture.Cancel {
+				if err := capture.Binding.UnmarshalText([]byte(text)); err != nil {
+					return nil, 0, fmt.Errorf("input: bad recording capture on frame %d: %w", len(script.Frames)-1, err)
+				}
+			}
+			script.Captures[len(script.Frames)-1] = capture
+
+			continue
+		}
+		var state KeyState
+		var count int
+		if _, err := fmt.Sscanf(line, "%x %d", &state, &count); err != nil {
+			return nil, 0, fmt.Errorf("input: bad recording frame %d: %w", len(script.Frames), err)
+		}
+		for range count {
+			script.Frames = append(script.Frames, state)
+		}
+	}
+}
+
+// InputRecorder writes the state of each frame as runs of "state count" lines.
+type InputRecorder struct {
+	w     *bufio.Writer
+	state KeyState
+	count int
+}
+
+func NewInputRecorder(w io.Writer, seed uint64) (*InputRecorder, error) {
+	r := &InputRecorder{w: bufio.NewWriter(w)}
+	if _, err := fmt.Fprintf(r.w, "seed %d\n", seed); err != nil {
+		return nil, err
+	}
+
+	return r, r.w.Flush()
+}
+
+func (r *InputRecorder) Record(state KeyState) error {
+	if r.count > 0 && state != r.state {
+		if err := r.writeRun(); err != nil {
+			return err
+		}
+	}
+	r.state = state
+	r.count++
+
+	return nil
+}
+
+// RecordCapture writes capture for the last recorded frame.
+func (r *InputRecorder) RecordCapture(capture Capture) error {
+	if err := r.writeRun(); err != nil {
+		return err
+	}
+	text := captureCancel
+	if !capture.Cancel {
+		text = capture.Binding.String()
+	}
+	if _, err := fmt.Fprintf(r.w, "%s%s\n", captureLine, text); err != nil {
+		return err
+	}
+
+	return r.w.Flush()
+}
+
+func (r *InputRecorder) Close() error { return r.writeRun() }
+
+func (r *InputRecorder) writeRun() error {
+	if r.count == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(r.w, "%x %d\n", r.state, r.count); err != nil {
+		return err
+	}
+	r.count = 0
+
+	return r.w.Flush()
+}
@@ -0,0 +1,98 @@
+package utils_test
+
+import (
+	"bytes"
+	"game/utils"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestControls(t *testing.T) {
+	script := &utils.InputScript{}
+	script.Press(1, utils.KeyJump)
+	utils.Input = script
+	defer func() { utils.Input = nil }()
+
+	controls, other := utils.NewControls(), utils.NewControls()
+	pack := utils.NewControlPack()
+	var pressed, released []bool
+	for range 3 {
+		controls.Update(pack)
+		pressed = append(pressed, controls.KeyPressed(utils.KeyJump))
+		released = append(released, controls.KeyReleased(utils.KeyJump))
+	}
+
+	if pressed[0] || !pressed[1] || pressed[2] {
+		t.Errorf("jump pressed on frames %v, want only on the second", pressed)
+	}
+	if released[0] || released[1] || !released[2] {
+		t.Errorf("jump released on frames %v, want only on the third", released)
+	}
+	if other.KeyDown(utils.KeyJump) || other.KeyReleased(utils.KeyJump) {
+		t.Error("controls never updated see the keys of other controls")
+	}
+}
+
+func TestKeyPressedBuffered(t *testing.T) {
+	script := &utils.InputScript{}
+	script.Press(0, utils.KeyAction)
+	script.Press(2, utils.KeyAction)
+	utils.Input = script
+	defer func() { utils.Input = nil }()
+
+	controls, pack := utils.NewControls(), utils.NewControlPack()
+	buffered := func(tick float64) bool {
+		controls.Update(pack)
+		controls.KeyPressedBuffered(utils.KeyAction, 0.5)
+		controls.Update(pack)
+		controls.TickBuffers(tick)
+
+		return controls.KeyPressedBuffered(utils.KeyAction, 0.5)()
+	}
+	if !buffered(0.3) {
+		t.Error("press not buffered 0.3s after it")
+	}
+	if buffered(0.6) {
+		t.Error("press still buffered 0.6s after it")
+	}
+}
+
+func TestRecordCapture(t *testing.T) {
+	var recording bytes.Buffer
+	recorder, err := utils.NewInputRecorder(&recording, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jump := utils.KeyState(0).With(utils.KeyJump)
+	captures := map[int]utils.Capture{1: {Binding: utils.KeyBinding(ebiten.KeyC)}, 2: {Cancel: true}}
+	for frame := range 4 {
+		if err := recorder.Record(jump); err != nil {
+			t.Fatal(err)
+		}
+		if capture, ok := captures[frame]; ok {
+			if err := recorder.RecordCapture(capture); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	script, seed, err := utils.ReadInputScript(&recording)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seed != 3 || len(script.Frames) != 4 {
+		t.Fatalf("read seed %d and %d frames, want 3 and 4", seed, len(script.Frames))
+	}
+	for frame := range 4 {
+		if state := script.State(); state != jump {
+			t.Errorf("frame %d state = %x, want %x", frame, state, jump)
+		}
+		if capture, ok := script.Capture(); capture != captures[frame] || ok != (frame == 1 || frame == 2) {
+			t.Errorf("frame %d capture = %+v, %v, want %+v", frame, capture, ok, captures[frame])
+		}
+	}
+}
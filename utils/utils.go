@@ -3,14 +3,13 @@ package utils
 import (
 	"game/assets"
 	"math"
-	"time"
+	"math/rand/v2"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
-type ControlPack [9][]ebiten.Key
+type ControlPack [10][]Binding
 type ControlKey int
 
 const (
@@ -23,74 +22,62 @@ const (
 	KeyGuard
 	KeyHeal
 	KeyDash
+	KeyMenu
 )
 
-var buffer = map[ControlKey]bool{}
-var bufferTimers = map[ControlKey]*time.Timer{}
+// Rand is the source of every gameplay random roll, seed it to make a run deterministic.
+var Rand = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())) //nolint: gosec
+
+func Seed(seed uint64) { Rand = rand.New(rand.NewPCG(seed, seed)) } //nolint: gosec
 
 func NewControlPack() ControlPack {
 	return ControlPack{
-		KeyRight:  {ebiten.KeyArrowRight, ebiten.KeyD},
-		KeyLeft:   {ebiten.KeyArrowLeft, ebiten.KeyA},
-		KeyUp:     {ebiten.KeyArrowUp, ebiten.KeyW},
-		KeyDown:   {ebiten.KeyArrowDown, ebiten.KeyS},
-		KeyJump:   {ebiten.KeyZ, ebiten.KeyN},
-		KeyAction: {ebiten.KeyX, ebiten.KeyM},
-		KeyGuard:  {ebiten.KeyC, ebiten.KeyB},
-		KeyHeal:   {ebiten.KeyV, ebiten.KeyShiftLeft, ebiten.KeyShiftRight},
-		//KeyDash:   {ebiten.KeySpace}, // TODO: Reconsider dash mechanic
-	}
-}
-
-func (cp ControlPack) KeyDown(key ControlKey) bool {
-	for _, key := range cp[key] {
-		if ebiten.IsKeyPressed(key) {
-			return true
-		}
+		KeyRight: {
+			KeyBinding(ebiten.KeyArrowRight), KeyBinding(ebiten.KeyD),
+			ButtonBinding(ebiten.StandardGamepadButtonLeftRight), AxisBinding(ebiten.StandardGamepadAxisLeftStickHorizontal, 1),
+		},
+		KeyLeft: {
+			KeyBinding(ebiten.KeyArrowLeft), KeyBinding(ebiten.KeyA),
+			ButtonBinding(ebiten.StandardGamepadButtonLeftLeft), AxisBinding(ebiten.StandardGamepadAxisLeftStickHorizontal, -1),
+		},
+		KeyUp: {
+			KeyBinding(ebiten.KeyArrowUp), KeyBinding(ebiten.KeyW),
+			ButtonBinding(ebiten.StandardGamepadButtonLeftTop), AxisBinding(ebiten.StandardGamepadAxisLeftStickVertical, -1),
+		},
+		KeyDown: {
+			KeyBinding(ebiten.KeyArrowDown), KeyBinding(ebiten.KeyS),
+			ButtonBinding(ebiten.StandardGamepadButtonLeftBottom), AxisBinding(ebiten.StandardGamepadAxisLeftStickVertical, 1),
+		},
+		KeyJump:   {KeyBinding(ebiten.KeyZ), KeyBinding(ebiten.KeyN), ButtonBinding(ebiten.StandardGamepadButtonRightBottom)},
+		KeyAction: {KeyBinding(ebiten.KeyX), KeyBinding(ebiten.KeyM), ButtonBinding(ebiten.StandardGamepadButtonRightLeft)},
+		KeyGuard:  {KeyBinding(ebiten.KeyC), KeyBinding(ebiten.KeyB), ButtonBinding(ebiten.StandardGamepadButtonFrontTopLeft)},
+		KeyHeal: {
+			KeyBinding(ebiten.KeyV), KeyBinding(ebiten.KeyShiftLeft), KeyBinding(ebiten.KeyShiftRight),
+			ButtonBinding(ebiten.StandardGamepadButtonRightTop),
+		},
+		//KeyDash:   {KeyBinding(ebiten.KeySpace)}, // TODO: Reconsider dash mechanic
+		KeyMenu: {
+			KeyBinding(ebiten.KeyEnter), KeyBinding(ebiten.KeyEscape),
+			ButtonBinding(ebiten.StandardGamepadButtonCenterRight),
+		},
 	}
-
-	return false
 }
 
-func (cp ControlPack) KeyPressed(key ControlKey) bool {
-	for _, key := range cp[key] {
-		if inpututil.IsKeyJustPressed(key) {
-			return true
+// State reads the keyboard and gamepads, ignoring Input.
+func (cp ControlPack) State() KeyState {
+	var state KeyState
+	gamepadIDs = ebiten.AppendGamepadIDs(gamepadIDs[:0])
+	for key, bindings := range cp {
+		for _, binding := range bindings {
+			if binding.Down() {
+				state = state.With(ControlKey(key))
+
+				break
+			}
 		}
 	}
 
-	return false
-}
-
-func (cp ControlPack) KeyReleased(key ControlKey) bool {
-	for _, key := range cp[key] {
-		if inpututil.IsKeyJustReleased(key) {
-			return true
-		}
-	}
-
-	return false
-}
-
-func (cp ControlPack) KeyPressedBuffered(key ControlKey, timeBuffer time.Duration) func() bool {
-	pressed := cp.KeyPressed(key)
-	if pressed {
-		buffer[key] = true
-		if bufferTimers[key] != nil {
-			bufferTimers[key].Stop()
-		}
-		bufferTimers[key] = time.AfterFunc(timeBuffer, func() { buffer[key] = false })
-	}
-
-	return func() bool {
-		pressed := buffer[key]
-		buffer[key] = false
-		if bufferTimers[key] != nil {
-			bufferTimers[key].Stop()
-		}
-
-		return pressed
-	}
+	return state
 }
 
 func Distante(x1, y1, x2, y2 float64) float64 {
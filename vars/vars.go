@@ -3,6 +3,7 @@ package vars
 
 import (
 	"game/core"
+	"game/libs/sound"
 	"game/utils"
 )
 
@@ -17,10 +18,6 @@ const (
 	PipelineScreenTag    = "screen"
 	PipelineNormalMapTag = "normal"
 
-	// Entity Flags.
-	PlayerTeamFlag = iota
-	EnemyTeamFlag
-
 	// Anim.
 	IdleTag       = "Idle"
 	WalkTag       = "Walk"
@@ -72,6 +69,7 @@ const (
 	// Body.
 	Gravity                     = 300.0
 	DefaultMaxX, DefaultMaxY    = 20.0, 200.0
+	DefaultJumpSpeed            = 110.0
 	GroundFriction, AirFriction = 8.0, 2.0 // TODO: Tune this variables. They might be too high.
 	CollisionStiffness          = 1.0
 	FrictionEpsilon             = 0.05
@@ -82,10 +80,9 @@ var (
 	// Global.
 	World  *core.World
 	Player core.Entity
-
-	// Signaling.
-	SaveGame, ResetGame bool
+	Audio  *sound.Manager
 
 	// Player.
-	Pad utils.ControlPack
+	Pad      utils.ControlPack
+	Controls = utils.NewControls()
 )